@@ -0,0 +1,182 @@
+package squirrel
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+)
+
+// bulkSet holds the rows of UpdateBuilder.BulkSet, keyed by the value of the
+// key column.
+type bulkSet struct {
+	key  string
+	rows map[interface{}]map[string]interface{}
+}
+
+// sortedKeys returns the keys of the rows in a stable order so that the same
+// rows always render the same statement.
+func (s *bulkSet) sortedKeys() []interface{} {
+	keys := make([]interface{}, 0, len(s.rows))
+	for key := range s.rows {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool { return lessKey(keys[i], keys[j]) })
+	return keys
+}
+
+// sortedColumns returns every column set by at least one row, sorted.
+func (s *bulkSet) sortedColumns() []string {
+	seen := make(map[string]bool)
+	var columns []string
+	for _, row := range s.rows {
+		for column := range row {
+			if !seen[column] {
+				seen[column] = true
+				columns = append(columns, column)
+			}
+		}
+	}
+	sort.Strings(columns)
+	return columns
+}
+
+func lessKey(a, b interface{}) bool {
+	va, vb := reflect.ValueOf(a), reflect.ValueOf(b)
+	switch {
+	case va.CanInt() && vb.CanInt():
+		return va.Int() < vb.Int()
+	case va.CanUint() && vb.CanUint():
+		return va.Uint() < vb.Uint()
+	case va.CanFloat() && vb.CanFloat():
+		return va.Float() < vb.Float()
+	case va.Kind() == reflect.String && vb.Kind() == reflect.String:
+		return va.String() < vb.String()
+	}
+	return fmt.Sprint(a) < fmt.Sprint(b)
+}
+
+// bindValue returns v as a Sqlizer, binding it to a placeholder unless it
+// already is one.
+func bindValue(v interface{}) Sqlizer {
+	if s, ok := v.(Sqlizer); ok {
+		return s
+	}
+	return Expr("?", v)
+}
+
+// caseSets renders the rows as one "column = CASE key WHEN ? THEN ? ... ELSE
+// column END" clause per column, restricted to the rows by "key IN (...)".
+func (s *bulkSet) caseSets() ([]setClause, Sqlizer) {
+	keys := s.sortedKeys()
+	columns := s.sortedColumns()
+
+	sets := make([]setClause, len(columns))
+	for i, column := range columns {
+		c := Case(s.key)
+		for _, key := range keys {
+			if value, ok := s.rows[key][column]; ok {
+				c = c.When(Expr("?", key), bindValue(value))
+			}
+		}
+		sets[i] = setClause{column: column, value: c.Else(column)}
+	}
+	return sets, Eq{s.key: keys}
+}
+
+// valuesSets renders the rows as a "(VALUES ...) AS v(key, columns...)" table
+// joined to table by the key column, as PostgreSQL prefers. PostgreSQL types
+// unknown parameters in VALUES as text, so values are cast to the type types
+// declares for their column, or else to the type of their basic Go type.
+// Sqlizer values are rendered as is.
+func (s *bulkSet) valuesSets(table string, types map[string]string) ([]setClause, Sqlizer, Sqlizer, error) {
+	keys := s.sortedKeys()
+	columns := s.sortedColumns()
+
+	sql := &bytes.Buffer{}
+	var args []interface{}
+	sql.WriteString("(VALUES ")
+	for i, key := range keys {
+		row := s.rows[key]
+		if len(row) != len(columns) {
+			return nil, nil, nil, fmt.Errorf("BulkSet row %v must set all of %s", key, strings.Join(columns, ", "))
+		}
+		if i > 0 {
+			sql.WriteString(", ")
+		}
+		sql.WriteString("(")
+		values := append([]interface{}{key}, make([]interface{}, len(columns))...)
+		names := append([]string{s.key}, columns...)
+		for j, column := range columns {
+			values[j+1] = row[column]
+		}
+		for j, value := range values {
+			if j > 0 {
+				sql.WriteString(", ")
+			}
+			vSql, vArgs, err := bindValue(value).ToSql()
+			if err != nil {
+				return nil, nil, nil, err
+			}
+			sql.WriteString(vSql)
+			if t, ok := types[names[j]]; ok {
+				sql.WriteString("::" + t)
+			} else if _, ok := value.(Sqlizer); !ok {
+				sql.WriteString(pgCast(value))
+			}
+			args = append(args, vArgs...)
+		}
+		sql.WriteString(")")
+	}
+	fmt.Fprintf(sql, ") AS v(%s, %s)", s.key, strings.Join(columns, ", "))
+
+	sets := make([]setClause, len(columns))
+	for i, column := range columns {
+		sets[i] = setClause{column: column, value: Expr("v." + column)}
+	}
+	where := Expr(fmt.Sprintf("%s.%s = v.%s", tableAlias(table), s.key, s.key))
+	return sets, Expr(sql.String(), args...), where, nil
+}
+
+// pgCast returns the PostgreSQL cast for the Go type of v, if any.
+func pgCast(v interface{}) string {
+	if _, ok := v.(time.Time); ok {
+		return "::timestamptz"
+	}
+	if _, ok := v.([]byte); ok {
+		return "::bytea"
+	}
+	switch reflect.ValueOf(v).Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "::bigint"
+	case reflect.Float32, reflect.Float64:
+		return "::double precision"
+	case reflect.Bool:
+		return "::boolean"
+	case reflect.String:
+		return "::text"
+	}
+	return ""
+}
+
+// bulkSetParts returns the SET clauses, FROM table and WHERE predicate that
+// apply d.BulkSet for d.Dialect.
+func (d *updateData) bulkSetParts() (sets []setClause, from Sqlizer, where Sqlizer, err error) {
+	if len(d.BulkSet.rows) == 0 {
+		err = errors.New("BulkSet must have at least one row")
+		return
+	}
+	if d.Dialect != PostgreSQL {
+		sets, where = d.BulkSet.caseSets()
+		return
+	}
+	if d.From != nil {
+		err = errors.New("postgres BulkSet cannot be combined with From")
+		return
+	}
+	return d.BulkSet.valuesSets(d.Table, d.BulkSetTypes)
+}
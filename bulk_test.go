@@ -0,0 +1,69 @@
+package squirrel
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUpdateBuilderBulkSet(t *testing.T) {
+	rows := map[interface{}]map[string]interface{}{
+		2: {"name": "larry"},
+		1: {"name": "moe", "age": 42},
+	}
+	sql, args, err := Update("users").BulkSet("id", rows).Set("updated", true).Where("active").ToSql()
+	assert.NoError(t, err)
+	expectedSql := "UPDATE users SET " +
+		"age = CASE id WHEN ? THEN ? ELSE age END, " +
+		"name = CASE id WHEN ? THEN ? WHEN ? THEN ? ELSE name END, " +
+		"updated = ? " +
+		"WHERE id IN (?,?) AND active"
+	assert.Equal(t, expectedSql, sql)
+	assert.Equal(t, []interface{}{1, 42, 1, "moe", 2, "larry", true, 1, 2}, args)
+
+	_, _, err = Update("users").BulkSet("id", nil).ToSql()
+	assert.Error(t, err)
+
+	sql, _, err = Update("users").BulkSet("id", rows).ClearSet().Set("a", 1).ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "UPDATE users SET a = ?", sql)
+}
+
+func TestUpdateBuilderBulkSetPostgres(t *testing.T) {
+	rows := map[interface{}]map[string]interface{}{
+		"b": {"n": 2, "note": nil},
+		"a": {"n": 1, "note": Expr("UPPER(?)", "x")},
+	}
+	sql, args, err := Update("items i").
+		BulkSet("code", rows).
+		Dialect(PostgreSQL).
+		PlaceholderFormat(Dollar).
+		ToSql()
+	assert.NoError(t, err)
+	expectedSql := "UPDATE items i SET n = v.n, note = v.note " +
+		"FROM (VALUES ($1::text, $2::bigint, UPPER($3)), ($4::text, $5::bigint, $6)) AS v(code, n, note) " +
+		"WHERE i.code = v.code"
+	assert.Equal(t, expectedSql, sql)
+	assert.Equal(t, []interface{}{"a", 1, "x", "b", 2, nil}, args)
+
+	delete(rows["b"], "note")
+	_, _, err = Update("items").BulkSet("code", rows).Dialect(PostgreSQL).ToSql()
+	assert.Error(t, err)
+}
+
+func TestUpdateBuilderBulkSetTypes(t *testing.T) {
+	rows := map[interface{}]map[string]interface{}{
+		"9b2c": {"role": "admin", "doc": Expr("?::jsonb", `{"a":1}`), "n": 1},
+	}
+	sql, args, err := Update("users").
+		BulkSet("id", rows).
+		BulkSetTypes(map[string]string{"id": "uuid", "role": "user_role"}).
+		Dialect(PostgreSQL).
+		ToSql()
+	assert.NoError(t, err)
+	expectedSql := "UPDATE users SET doc = v.doc, n = v.n, role = v.role " +
+		"FROM (VALUES (?::uuid, ?::jsonb, ?::bigint, ?::user_role)) AS v(id, doc, n, role) " +
+		"WHERE users.id = v.id"
+	assert.Equal(t, expectedSql, sql)
+	assert.Equal(t, []interface{}{"9b2c", `{"a":1}`, 1, "admin"}, args)
+}
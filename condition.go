@@ -1,68 +1,152 @@
 package squirrel
 
-type WhereConditions interface {
+import "context"
+
+// WhereConditions is the set of WHERE, GROUP BY, HAVING, ORDER BY, LIMIT and
+// OFFSET methods shared by every builder. B is the concrete builder type the
+// methods return, so a chain keeps its type no matter the order methods are
+// called in. Helpers that should work with any builder can be written as
+//
+//	func active[B WhereConditions[B]](b B) B { return b.Eq("active", true) }
+type WhereConditions[B any] interface {
 	ToSql() (string, []interface{}, error)
-	PlaceholderFormat(PlaceholderFormat) WhereConditions
-	Where(interface{}, ...interface{}) WhereConditions
-	Condition() WhereConditions
-	Expr(string, ...interface{}) WhereConditions
-	Eq(string, interface{}) WhereConditions
-	NotEq(string, interface{}) WhereConditions
-	Gt(string, interface{}) WhereConditions
-	GtOrEq(string, interface{}) WhereConditions
-	Lt(string, interface{}) WhereConditions
-	LtOrEq(string, interface{}) WhereConditions
-	OrderBy(...string) WhereConditions
-	GroupBy(...string) WhereConditions
-	Having(interface{}, ...interface{}) WhereConditions
-	Limit(int) WhereConditions
-	Offset(int) WhereConditions
-	Suffix(string, ...interface{}) WhereConditions
+	PlaceholderFormat(PlaceholderFormat) B
+	Where(interface{}, ...interface{}) B
+	Condition() B
+	Expr(string, ...interface{}) B
+	Eq(string, interface{}) B
+	NotEq(string, interface{}) B
+	Gt(string, interface{}) B
+	GtOrEq(string, interface{}) B
+	Lt(string, interface{}) B
+	LtOrEq(string, interface{}) B
+	OrderBy(...string) B
+	GroupBy(...string) B
+	Having(interface{}, ...interface{}) B
+	Limit(int) B
+	Offset(int) B
+	Suffix(string, ...interface{}) B
 }
 
-type SelectCondition interface {
-	Prefix(string, ...interface{}) SelectCondition
-	Distinct() SelectCondition
-	Options(...string) SelectCondition
-	Columns(...string) SelectCondition
-	Column(interface{}, ...interface{}) SelectCondition
-	From(string) SelectCondition
-	FromSelect(SelectCondition, string) SelectCondition
-	JoinCondition
+// JoinCondition adds the JOIN methods to WhereConditions.
+type JoinCondition[B any] interface {
+	JoinClause(interface{}, ...interface{}) B
+	Join(string, ...interface{}) B
+	LeftJoin(string, ...interface{}) B
+	RightJoin(string, ...interface{}) B
+	WhereConditions[B]
 }
 
-type JoinCondition interface {
-	JoinClause(interface{}, ...interface{}) JoinCondition
-	Join(string, ...interface{}) JoinCondition
-	LeftJoin(string, ...interface{}) JoinCondition
-	RightJoin(string, ...interface{}) JoinCondition
-	WhereConditions
+type SelectCondition interface {
+	Prefix(string, ...interface{}) SelectBuilder
+	Distinct() SelectBuilder
+	Options(...string) SelectBuilder
+	Columns(...string) SelectBuilder
+	Column(interface{}, ...interface{}) SelectBuilder
+	ColumnsOf(interface{}, string) SelectBuilder
+	From(string) SelectBuilder
+	FromSelect(SelectCondition, string) SelectBuilder
+	Merge(ConditionFragment) SelectBuilder
+	CountQuery() SelectBuilder
+	Dialect(Dialect) SelectBuilder
+	SeekAfter(Cursor) SelectBuilder
+	RemoveColumns() SelectBuilder
+	ReplaceColumns(...string) SelectBuilder
+	WithDeleted() SelectBuilder
+	ClearWhere() SelectBuilder
+	RemoveOrderBy() SelectBuilder
+	RemoveLimit() SelectBuilder
+	RemoveOffset() SelectBuilder
+	ScanStruct(context.Context, interface{}) error
+	ScanAll(context.Context, interface{}) error
+	JoinCondition[SelectBuilder]
 }
 
 type UpdateCondition interface {
-	Prefix(string, ...interface{}) UpdateCondition
-	Table(string) UpdateCondition
-	Set(string, interface{}) UpdateCondition
-	IncrBy(string, int) UpdateCondition
-	DecrBy(string, int) UpdateCondition
-	SetMap(map[string]interface{}) UpdateCondition
-	WhereConditions
+	Prefix(string, ...interface{}) UpdateBuilder
+	Table(string) UpdateBuilder
+	From(string) UpdateBuilder
+	FromSelect(SelectCondition, string) UpdateBuilder
+	JoinClause(interface{}, ...interface{}) UpdateBuilder
+	Join(string, ...interface{}) UpdateBuilder
+	LeftJoin(string, ...interface{}) UpdateBuilder
+	Set(string, interface{}) UpdateBuilder
+	SetExpr(string, Sqlizer) UpdateBuilder
+	Incr(string, interface{}) UpdateBuilder
+	Decr(string, interface{}) UpdateBuilder
+	SetDefault(string) UpdateBuilder
+	SetNull(string) UpdateBuilder
+	IncrBy(string, int) UpdateBuilder
+	DecrBy(string, int) UpdateBuilder
+	SetMap(map[string]interface{}) UpdateBuilder
+	BulkSet(string, map[interface{}]map[string]interface{}) UpdateBuilder
+	BulkSetTypes(map[string]string) UpdateBuilder
+	SetStruct(interface{}, ...StructOption) UpdateBuilder
+	Merge(ConditionFragment) UpdateBuilder
+	Dialect(Dialect) UpdateBuilder
+	Returning(...string) UpdateBuilder
+	ClearSet() UpdateBuilder
+	AllRows() UpdateBuilder
+	WithDeleted() UpdateBuilder
+	WithVersion(string, interface{}) UpdateBuilder
+	ClearWhere() UpdateBuilder
+	RemoveOrderBy() UpdateBuilder
+	RemoveLimit() UpdateBuilder
+	RemoveOffset() UpdateBuilder
+	WhereConditions[UpdateBuilder]
 }
 
 type DeleteCondition interface {
-	Prefix(string, ...interface{}) DeleteCondition
-	From(string) DeleteCondition
-	WhereConditions
+	Prefix(string, ...interface{}) DeleteBuilder
+	From(string) DeleteBuilder
+	Targets(...string) DeleteBuilder
+	Using(...string) DeleteBuilder
+	JoinClause(interface{}, ...interface{}) DeleteBuilder
+	Join(string, ...interface{}) DeleteBuilder
+	LeftJoin(string, ...interface{}) DeleteBuilder
+	Merge(ConditionFragment) DeleteBuilder
+	Dialect(Dialect) DeleteBuilder
+	Returning(...string) DeleteBuilder
+	AllRows() DeleteBuilder
+	HardDelete() DeleteBuilder
+	ClearWhere() DeleteBuilder
+	RemoveOrderBy() DeleteBuilder
+	RemoveLimit() DeleteBuilder
+	RemoveOffset() DeleteBuilder
+	WhereConditions[DeleteBuilder]
 }
+
 type InsertCondition interface {
 	ToSql() (string, []interface{}, error)
-	PlaceholderFormat(PlaceholderFormat) InsertCondition
-	Prefix(string, ...interface{}) InsertCondition
-	Options(...string) InsertCondition
-	Into(string) InsertCondition
-	Columns(...string) InsertCondition
-	Values(...interface{}) InsertCondition
-	Suffix(string, ...interface{}) InsertCondition
-	SetMap(map[string]interface{}) InsertCondition
-	Select(SelectCondition) InsertCondition
+	PlaceholderFormat(PlaceholderFormat) InsertBuilder
+	Prefix(string, ...interface{}) InsertBuilder
+	Options(...string) InsertBuilder
+	Into(string) InsertBuilder
+	Columns(...string) InsertBuilder
+	Values(...interface{}) InsertBuilder
+	ValuesMap(map[string]interface{}) InsertBuilder
+	DefaultValues() InsertBuilder
+	Ignore() InsertBuilder
+	Suffix(string, ...interface{}) InsertBuilder
+	SetMap(map[string]interface{}) InsertBuilder
+	SetStruct(interface{}) InsertBuilder
+	ValuesStructs(interface{}) InsertBuilder
+	Select(Sqlizer) InsertBuilder
+	Dialect(Dialect) InsertBuilder
+	OnConflict(...string) InsertBuilder
+	DoUpdateSet(string, interface{}) InsertBuilder
+	DoUpdateSetMap(map[string]interface{}) InsertBuilder
+	DoNothing() InsertBuilder
+	OnDuplicateKeyUpdate(map[string]interface{}) InsertBuilder
+	Returning(...string) InsertBuilder
+	Batches(int) ([]Sqlizer, error)
 }
+
+var (
+	_ SelectCondition               = SelectBuilder{}
+	_ UpdateCondition               = UpdateBuilder{}
+	_ DeleteCondition               = DeleteBuilder{}
+	_ InsertCondition               = InsertBuilder{}
+	_ WhereConditions[WhereBuilder] = WhereBuilder{}
+	_ JoinCondition[JoinBuilder]    = JoinBuilder{}
+)
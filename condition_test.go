@@ -1,13 +1,36 @@
 package squirrel
 
-import "testing"
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
 
 func TestCondition(t *testing.T) {
-	s:=Select("a").From("b").Eq("a",1)
+	s := Select("a").From("b").Eq("a", 1)
 	a(s)
 }
 
-func a(c WhereConditions)  {
+func a[B WhereConditions[B]](c B) B {
 	var b interface{}
-	c=c.Eq("a",b)
-}
\ No newline at end of file
+	return c.Eq("a", b)
+}
+
+func TestConditionKeepsBuilderType(t *testing.T) {
+	b := a(Select("a").Where("b = ?", 1)).
+		Join("c ON c.id = b.c_id").
+		Column("d").
+		From("b").
+		Limit(10).
+		Where("e = ?", 2)
+
+	sql, args, err := b.ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT a, d FROM b JOIN c ON c.id = b.c_id WHERE b = ? AND a IS NULL AND e = ? LIMIT 10", sql)
+	assert.Equal(t, []interface{}{1, 2}, args)
+
+	u := a(Update("t").Where("x = ?", 1)).Set("y", 2)
+	sql, _, err = u.ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "UPDATE t SET y = ? WHERE x = ? AND a IS NULL", sql)
+}
@@ -2,6 +2,8 @@ package squirrel
 
 import (
 	"bytes"
+	"context"
+	"database/sql"
 	"fmt"
 	"strings"
 
@@ -9,16 +11,29 @@ import (
 )
 
 type deleteData struct {
+	statementPolicies
 	PlaceholderFormat PlaceholderFormat
+	Dialect           Dialect
+	RunWith           BaseRunner
+	AllRows           bool
 	Prefixes          exprs
 	From              string
+	Targets           []string
+	Usings            []string
+	Joins             []Sqlizer
 	WhereParts        []Sqlizer
 	OrderBys          []string
 	GroupBys          []string
 	HavingParts       []Sqlizer
 	Limit             string
 	Offset            string
+	Returning         []string
 	Suffixes          exprs
+	HardDelete        bool
+
+	// ctx is the context the statement is run with, for the Audit of soft
+	// deletes.
+	ctx context.Context
 }
 
 func (d *deleteData) ToSql() (sqlStr string, args []interface{}, err error) {
@@ -26,6 +41,39 @@ func (d *deleteData) ToSql() (sqlStr string, args []interface{}, err error) {
 		err = fmt.Errorf("delete statements must specify a From table")
 		return
 	}
+	if len(d.GroupBys) > 0 || len(d.HavingParts) > 0 {
+		err = fmt.Errorf("delete statements do not support GROUP BY or HAVING")
+		return
+	}
+
+	if d.softDeleted(d.From) && !d.HardDelete {
+		return d.softDeleteSql()
+	}
+
+	// MySQL and SQLServer name the tables to delete from before a FROM clause
+	// listing every table, the others list the other tables in USING.
+	multiTable := len(d.Targets) > 0 || len(d.Usings) > 0 || len(d.Joins) > 0
+	targetsFirst := d.Dialect == MySQL || d.Dialect == SQLServer || (d.Dialect == "" && len(d.Usings) == 0)
+	switch {
+	case d.Dialect == SQLite && multiTable:
+		err = fmt.Errorf("sqlite delete statements do not support joins")
+		return
+	case !targetsFirst && len(d.Targets) > 0:
+		err = fmt.Errorf("%s delete statements can only delete from the From table", d.Dialect)
+		return
+	case d.Dialect == PostgreSQL && len(d.Joins) > 0 && len(d.Usings) == 0:
+		err = fmt.Errorf("postgres delete statements can only join tables to Using")
+		return
+	}
+	targetsFirst = targetsFirst && multiTable
+
+	var returning string
+	if len(d.Returning) > 0 {
+		returning, err = d.Dialect.returningClause(d.Returning, "DELETED")
+		if err != nil {
+			return
+		}
+	}
 
 	sql := &bytes.Buffer{}
 
@@ -34,17 +82,83 @@ func (d *deleteData) ToSql() (sqlStr string, args []interface{}, err error) {
 		sql.WriteString(" ")
 	}
 
-	sql.WriteString("DELETE FROM ")
-	sql.WriteString(d.From)
+	sql.WriteString("DELETE ")
+	if targetsFirst {
+		targets := d.Targets
+		if len(targets) == 0 {
+			targets = []string{tableAlias(d.From)}
+		}
+		sql.WriteString(strings.Join(targets, ", "))
+		sql.WriteString(" ")
+		if len(returning) > 0 && d.Dialect == SQLServer {
+			sql.WriteString(returning)
+			sql.WriteString(" ")
+		}
+	}
+	sql.WriteString("FROM ")
+	// MySQL before 8.0.16 and SQLServer cannot alias the table of a single
+	// table delete.
+	sql.WriteString(d.resolveTable(d.From, targetsFirst || (d.Dialect != SQLServer && d.Dialect != MySQL)))
+
+	if len(returning) > 0 && d.Dialect == SQLServer && !targetsFirst {
+		sql.WriteString(" ")
+		sql.WriteString(returning)
+	}
+
+	if len(d.Usings) > 0 {
+		if targetsFirst {
+			sql.WriteString(", ")
+		} else {
+			sql.WriteString(" USING ")
+		}
+		for i, using := range d.Usings {
+			if i > 0 {
+				sql.WriteString(", ")
+			}
+			sql.WriteString(d.resolveTable(using, true))
+		}
+	}
 
-	if len(d.WhereParts) > 0 {
-		sql.WriteString(" WHERE ")
-		args, err = appendToSql(d.WhereParts, sql, " AND ", args)
+	joins, scopes, err := d.scopeJoins(d.Joins, true)
+	if err != nil {
+		return
+	}
+	joins, err = d.resolveJoins(joins)
+	if err != nil {
+		return
+	}
+	if len(joins) > 0 {
+		sql.WriteString(" ")
+		args, err = appendToSql(joins, sql, " ", args)
 		if err != nil {
 			return
 		}
 	}
 
+	if err = d.checkTenantTables(append([]string{d.From}, d.Usings...)...); err != nil {
+		return
+	}
+	tableScopes := d.tableScopes(d.From, multiTable, true)
+	for _, using := range d.Usings {
+		tableScopes = append(tableScopes, d.tableScopes(using, true, true)...)
+	}
+	scopes = append(tableScopes, scopes...)
+
+	var hasWhere bool
+	args, hasWhere, err = appendScopedWhereToSql(d.WhereParts, scopes, sql, args)
+	if err != nil {
+		return
+	}
+	if d.RequireWhere && !hasWhere && !d.AllRows {
+		err = ErrMissingWhere
+		return
+	}
+
+	if len(returning) > 0 && d.Dialect != SQLServer {
+		sql.WriteString(" ")
+		sql.WriteString(returning)
+	}
+
 	if len(d.OrderBys) > 0 {
 		sql.WriteString(" ORDER BY ")
 		sql.WriteString(strings.Join(d.OrderBys, ", "))
@@ -69,6 +183,67 @@ func (d *deleteData) ToSql() (sqlStr string, args []interface{}, err error) {
 	return
 }
 
+func (d *deleteData) Exec() (sql.Result, error) {
+	if d.RunWith == nil {
+		return nil, RunnerNotSet
+	}
+	return ExecWith(d.RunWith, d)
+}
+
+func (d *deleteData) Query() (*sql.Rows, error) {
+	if d.RunWith == nil {
+		return nil, RunnerNotSet
+	}
+	return QueryWith(d.RunWith, d)
+}
+
+func (d *deleteData) QueryRow() RowScanner {
+	if d.RunWith == nil {
+		return &Row{err: RunnerNotSet}
+	}
+	queryRower, ok := d.RunWith.(QueryRower)
+	if !ok {
+		return &Row{err: RunnerNotQueryRunner}
+	}
+	return QueryRowWith(queryRower, d)
+}
+
+func (d *deleteData) ExecContext(ctx context.Context) (sql.Result, error) {
+	if d.RunWith == nil {
+		return nil, RunnerNotSet
+	}
+	ctxRunner, ok := d.RunWith.(ExecerContext)
+	if !ok {
+		return nil, NoContextSupport
+	}
+	return ExecContextWith(ctx, ctxRunner, d.withContext(ctx))
+}
+
+func (d *deleteData) QueryContext(ctx context.Context) (*sql.Rows, error) {
+	if d.RunWith == nil {
+		return nil, RunnerNotSet
+	}
+	ctxRunner, ok := d.RunWith.(QueryerContext)
+	if !ok {
+		return nil, NoContextSupport
+	}
+	return QueryContextWith(ctx, ctxRunner, d.withContext(ctx))
+}
+
+func (d *deleteData) QueryRowContext(ctx context.Context) RowScanner {
+	if d.RunWith == nil {
+		return &Row{err: RunnerNotSet}
+	}
+	queryRower, ok := d.RunWith.(QueryRowerContext)
+	if !ok {
+		if _, ok := d.RunWith.(QueryerContext); !ok {
+			return &Row{err: RunnerNotQueryRunner}
+		}
+		return &Row{err: NoContextSupport}
+	}
+	return QueryRowContextWith(ctx, queryRower, d.withContext(ctx))
+}
+
 // Builder
 
 // DeleteBuilder builds SQL DELETE statements.
@@ -82,10 +257,74 @@ func init() {
 
 // PlaceholderFormat sets PlaceholderFormat (e.g. Question or Dollar) for the
 // query.
-func (b DeleteBuilder) PlaceholderFormat(f PlaceholderFormat) WhereConditions {
+func (b DeleteBuilder) PlaceholderFormat(f PlaceholderFormat) DeleteBuilder {
 	return builder.Set(b, "PlaceholderFormat", f).(DeleteBuilder)
 }
 
+// Dialect sets the Dialect (e.g. MySQL or PostgreSQL) the query is rendered
+// for.
+func (b DeleteBuilder) Dialect(d Dialect) DeleteBuilder {
+	return builder.Set(b, "Dialect", d).(DeleteBuilder)
+}
+
+// Runner methods
+
+// RunWith sets a Runner (like database/sql.DB) to be used with e.g. Exec.
+// For most cases runner will be a database connection.
+//
+// Internally we use this to mock out the database connection for testing.
+func (b DeleteBuilder) RunWith(runner BaseRunner) DeleteBuilder {
+	return setRunWith(b, runner).(DeleteBuilder)
+}
+
+// Exec builds and Execs the query with the Runner set by RunWith.
+func (b DeleteBuilder) Exec() (sql.Result, error) {
+	data := builder.GetStruct(b).(deleteData)
+	return data.Exec()
+}
+
+// Query builds and Querys the query with the Runner set by RunWith.
+func (b DeleteBuilder) Query() (*sql.Rows, error) {
+	data := builder.GetStruct(b).(deleteData)
+	return data.Query()
+}
+
+// QueryRow builds and QueryRows the query with the Runner set by RunWith.
+func (b DeleteBuilder) QueryRow() RowScanner {
+	data := builder.GetStruct(b).(deleteData)
+	return data.QueryRow()
+}
+
+// Scan is a shortcut for QueryRow().Scan.
+func (b DeleteBuilder) Scan(dest ...interface{}) error {
+	return b.QueryRow().Scan(dest...)
+}
+
+// ExecContext builds and ExecContexts the query with the Runner set by RunWith.
+func (b DeleteBuilder) ExecContext(ctx context.Context) (sql.Result, error) {
+	data := builder.GetStruct(b).(deleteData)
+	return data.ExecContext(ctx)
+}
+
+// QueryContext builds and QueryContexts the query with the Runner set by
+// RunWith.
+func (b DeleteBuilder) QueryContext(ctx context.Context) (*sql.Rows, error) {
+	data := builder.GetStruct(b).(deleteData)
+	return data.QueryContext(ctx)
+}
+
+// QueryRowContext builds and QueryRowContexts the query with the Runner set by
+// RunWith.
+func (b DeleteBuilder) QueryRowContext(ctx context.Context) RowScanner {
+	data := builder.GetStruct(b).(deleteData)
+	return data.QueryRowContext(ctx)
+}
+
+// ScanContext is a shortcut for QueryRowContext().Scan.
+func (b DeleteBuilder) ScanContext(ctx context.Context, dest ...interface{}) error {
+	return b.QueryRowContext(ctx).Scan(dest...)
+}
+
 // SQL methods
 
 // ToSql builds the query into a SQL string and bound args.
@@ -95,86 +334,215 @@ func (b DeleteBuilder) ToSql() (string, []interface{}, error) {
 }
 
 // Prefix adds an expression to the beginning of the query
-func (b DeleteBuilder) Prefix(sql string, args ...interface{}) DeleteCondition {
+func (b DeleteBuilder) Prefix(sql string, args ...interface{}) DeleteBuilder {
 	return builder.Append(b, "Prefixes", Expr(sql, args...)).(DeleteBuilder)
 }
 
 // From sets the table to be deleted from.
-func (b DeleteBuilder) From(from string) DeleteCondition {
+func (b DeleteBuilder) From(from string) DeleteBuilder {
 	return builder.Set(b, "From", from).(DeleteBuilder)
 }
 
+// Targets sets the tables rows are deleted from in a multi-table delete, as in
+// "DELETE t1, t2 FROM t1 JOIN t2 ON ...". It defaults to the From table, or
+// its alias. Only MySQL and SQLServer support deleting from joined tables.
+func (b DeleteBuilder) Targets(targets ...string) DeleteBuilder {
+	return builder.Extend(b, "Targets", targets).(DeleteBuilder)
+}
+
+// Using adds tables the deleted rows are matched against, rendered as
+// "DELETE FROM t USING a, b" for PostgreSQL or as "DELETE t FROM t, a, b" for
+// MySQL and SQLServer.
+func (b DeleteBuilder) Using(tables ...string) DeleteBuilder {
+	return builder.Extend(b, "Usings", tables).(DeleteBuilder)
+}
+
+// JoinClause adds a join clause to the query.
+//
+// PostgreSQL joins to the tables set by Using, the other dialects to the From
+// table.
+func (b DeleteBuilder) JoinClause(pred interface{}, args ...interface{}) DeleteBuilder {
+	return builder.Append(b, "Joins", newPart(pred, args...)).(DeleteBuilder)
+}
+
+// Join adds a JOIN clause to the query.
+//
+// See JoinClause.
+func (b DeleteBuilder) Join(join string, rest ...interface{}) DeleteBuilder {
+	return b.JoinClause("JOIN "+join, rest...)
+}
+
+// LeftJoin adds a LEFT JOIN clause to the query.
+//
+// See JoinClause.
+func (b DeleteBuilder) LeftJoin(join string, rest ...interface{}) DeleteBuilder {
+	return b.JoinClause("LEFT JOIN "+join, rest...)
+}
+
 // Where adds WHERE expressions to the query.
 //
 // See SelectBuilder.Where for more information.
-func (b DeleteBuilder) Where(pred interface{}, args ...interface{}) WhereConditions {
+func (b DeleteBuilder) Where(pred interface{}, args ...interface{}) DeleteBuilder {
 	return builder.Append(b, "WhereParts", newWherePart(pred, args...)).(DeleteBuilder)
 }
 
 //Condition
-func (b DeleteBuilder) Condition() WhereConditions {
+func (b DeleteBuilder) Condition() DeleteBuilder {
 	return builder.Append(b, "WhereParts", newWherePart("")).(DeleteBuilder)
 }
 
 //expr
-func (b DeleteBuilder) Expr(sql string, args ...interface{}) WhereConditions {
+func (b DeleteBuilder) Expr(sql string, args ...interface{}) DeleteBuilder {
 	return builder.Append(b, "WhereParts", newWherePart(expr{sql: sql, args: args})).(DeleteBuilder)
 }
 
 //eq
-func (b DeleteBuilder) Eq(column string, arg interface{}) WhereConditions {
+func (b DeleteBuilder) Eq(column string, arg interface{}) DeleteBuilder {
 	return b.Where(Eq{column: arg})
 }
 
-func (b DeleteBuilder) NotEq(column string, arg interface{}) WhereConditions {
+func (b DeleteBuilder) NotEq(column string, arg interface{}) DeleteBuilder {
 	return b.Where(NotEq{column: arg})
 }
 
 //gt
-func (b DeleteBuilder) Gt(column string, arg interface{}) WhereConditions {
+func (b DeleteBuilder) Gt(column string, arg interface{}) DeleteBuilder {
 	return b.Where(Gt{column: arg})
 }
 
 //gtOrEq
-func (b DeleteBuilder) GtOrEq(column string, arg interface{}) WhereConditions {
+func (b DeleteBuilder) GtOrEq(column string, arg interface{}) DeleteBuilder {
 	return b.Where(GtOrEq{column: arg})
 }
 
 //lt
-func (b DeleteBuilder) Lt(column string, arg interface{}) WhereConditions {
+func (b DeleteBuilder) Lt(column string, arg interface{}) DeleteBuilder {
 	return b.Where(Lt{column: arg})
 }
 
 //ltOrEq
-func (b DeleteBuilder) LtOrEq(column string, arg interface{}) WhereConditions {
+func (b DeleteBuilder) LtOrEq(column string, arg interface{}) DeleteBuilder {
 	return b.Where(LtOrEq{column: arg})
 }
 
 // OrderBy adds ORDER BY expressions to the query.
-func (b DeleteBuilder) GroupBy(groupBys ...string) WhereConditions {
+func (b DeleteBuilder) GroupBy(groupBys ...string) DeleteBuilder {
 	return builder.Extend(b, "GroupBys", groupBys).(DeleteBuilder)
 }
 
-func (b DeleteBuilder) Having(pred interface{}, rest ...interface{}) WhereConditions {
+func (b DeleteBuilder) Having(pred interface{}, rest ...interface{}) DeleteBuilder {
 	return builder.Append(b, "HavingParts", newWherePart(pred, rest...)).(DeleteBuilder)
 }
 
 // OrderBy adds ORDER BY expressions to the query.
-func (b DeleteBuilder) OrderBy(orderBys ...string) WhereConditions {
+func (b DeleteBuilder) OrderBy(orderBys ...string) DeleteBuilder {
 	return builder.Extend(b, "OrderBys", orderBys).(DeleteBuilder)
 }
 
 // Limit sets a LIMIT clause on the query.
-func (b DeleteBuilder) Limit(limit int) WhereConditions {
+func (b DeleteBuilder) Limit(limit int) DeleteBuilder {
 	return builder.Set(b, "Limit", fmt.Sprintf("%d", limit)).(DeleteBuilder)
 }
 
 // Offset sets a OFFSET clause on the query.
-func (b DeleteBuilder) Offset(offset int) WhereConditions {
+func (b DeleteBuilder) Offset(offset int) DeleteBuilder {
 	return builder.Set(b, "Offset", fmt.Sprintf("%d", offset)).(DeleteBuilder)
 }
 
 // Suffix adds an expression to the end of the query
-func (b DeleteBuilder) Suffix(sql string, args ...interface{}) WhereConditions {
+func (b DeleteBuilder) Suffix(sql string, args ...interface{}) DeleteBuilder {
 	return builder.Append(b, "Suffixes", Expr(sql, args...)).(DeleteBuilder)
 }
+
+// Returning adds columns of the deleted rows to be returned by the query, in a
+// RETURNING clause or an OUTPUT clause for SQLServer. Read them with Query or
+// QueryRow.
+func (b DeleteBuilder) Returning(columns ...string) DeleteBuilder {
+	return builder.Extend(b, "Returning", columns).(DeleteBuilder)
+}
+
+// AllRows allows the statement to delete from every row of the table when
+// RequireWhere is set on the StatementBuilderType it was built from.
+func (b DeleteBuilder) AllRows() DeleteBuilder {
+	return builder.Set(b, "AllRows", true).(DeleteBuilder)
+}
+
+// HardDelete deletes the rows of a soft deleted table instead of marking them
+// as deleted.
+//
+// See StatementBuilderType.SoftDelete.
+func (b DeleteBuilder) HardDelete() DeleteBuilder {
+	return builder.Set(b, "HardDelete", true).(DeleteBuilder)
+}
+
+// ClearWhere removes all WHERE expressions from the query.
+func (b DeleteBuilder) ClearWhere() DeleteBuilder {
+	return builder.Delete(b, "WhereParts").(DeleteBuilder)
+}
+
+// RemoveOrderBy removes the ORDER BY clause from the query.
+func (b DeleteBuilder) RemoveOrderBy() DeleteBuilder {
+	return builder.Delete(b, "OrderBys").(DeleteBuilder)
+}
+
+// RemoveLimit removes the LIMIT clause from the query.
+func (b DeleteBuilder) RemoveLimit() DeleteBuilder {
+	return builder.Delete(b, "Limit").(DeleteBuilder)
+}
+
+// RemoveOffset removes the OFFSET clause from the query.
+func (b DeleteBuilder) RemoveOffset() DeleteBuilder {
+	return builder.Delete(b, "Offset").(DeleteBuilder)
+}
+
+// Merge adds the WHERE, ORDER BY, LIMIT, OFFSET and suffix parts of a fragment
+// built with Where or Condition to the query. A fragment with GROUP BY or
+// HAVING parts makes ToSql return an error, as delete statements have neither.
+//
+// See SelectBuilder.Merge.
+func (b DeleteBuilder) Merge(fragment ConditionFragment) DeleteBuilder {
+	return mergeFragment(b, fragment).(DeleteBuilder)
+}
+
+// withContext returns a copy of d run with ctx.
+func (d deleteData) withContext(ctx context.Context) *deleteData {
+	d.ctx = ctx
+	return &d
+}
+
+// softDeleteSql renders the delete as an update setting the soft delete
+// column of the deleted rows.
+func (d *deleteData) softDeleteSql() (string, []interface{}, error) {
+	if len(d.Targets) > 0 || len(d.Usings) > 0 || len(d.Joins) > 0 {
+		return "", nil, fmt.Errorf("soft deletes do not support multi-table deletes, use HardDelete")
+	}
+	u := updateData{
+		statementPolicies: d.statementPolicies,
+		PlaceholderFormat: d.PlaceholderFormat,
+		Dialect:           d.Dialect,
+		AllRows:           d.AllRows,
+		Prefixes:          d.Prefixes,
+		Table:             d.From,
+		SetClauses: []setClause{
+			{column: d.SoftDelete.column, value: Expr(d.Dialect.currentTimestamp())},
+		},
+		WhereParts: d.WhereParts,
+		OrderBys:   d.OrderBys,
+		Limit:      d.Limit,
+		Offset:     d.Offset,
+		Returning:  d.Returning,
+		Suffixes:   d.Suffixes,
+		ctx:        d.ctx,
+	}
+	return u.ToSql()
+}
+
+// tableAlias returns the name a table expression like "orders o" or
+// "orders AS o" is referred to by.
+func tableAlias(table string) string {
+	fields := strings.Fields(table)
+	if len(fields) == 0 {
+		return table
+	}
+	return fields[len(fields)-1]
+}
@@ -44,20 +44,121 @@ func TestDeleteBuilderToSqlErr(t *testing.T) {
 //	assert.Equal(t, "DELETE FROM test WHERE x = $1 AND y = $2", sql)
 //}
 
-//func TestDeleteBuilderRunners(t *testing.T) {
-//	db := &DBStub{}
-//	b := Delete("test").Where("x = ?", 1).RunWith(db)
-//
-//	expectedSql := "DELETE FROM test WHERE x = ?"
-//
-//	b.Exec()
-//	assert.Equal(t, expectedSql, db.LastExecSql)
-//}
+func TestDeleteBuilderRunners(t *testing.T) {
+	db := &DBStub{}
+	b := Delete("test").Where("x = ?", 1).RunWith(db)
+
+	expectedSql := "DELETE FROM test WHERE x = ?"
+
+	b.Exec()
+	assert.Equal(t, expectedSql, db.LastExecSql)
+}
 
 func TestDeleteBuilderNoRunner(t *testing.T) {
 	b := Delete("test")
 
-	//_, err := b.Exec()
-	//assert.Equal(t, RunnerNotSet, err)
-	t.Log(b)
+	_, err := b.Exec()
+	assert.Equal(t, RunnerNotSet, err)
+}
+
+func TestDeleteBuilderMerge(t *testing.T) {
+	tenant := Where("tenant_id = ?", 1)
+	sql, args, err := Delete("a").Where("b = ?", 2).Merge(tenant).ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "DELETE FROM a WHERE b = ? AND tenant_id = ?", sql)
+	assert.Equal(t, []interface{}{2, 1}, args)
+
+	_, _, err = Delete("a").Merge(Where("b = ?", 2).GroupBy("c")).ToSql()
+	assert.Error(t, err)
+}
+
+func TestDeleteBuilderRemoveClauses(t *testing.T) {
+	b := Delete("a").Where("b = ?", 1).OrderBy("c").Limit(2).Offset(3)
+
+	sql, args, err := b.ClearWhere().RemoveOrderBy().RemoveLimit().RemoveOffset().ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "DELETE FROM a", sql)
+	assert.Empty(t, args)
+}
+
+func TestDeleteBuilderReturning(t *testing.T) {
+	b := Delete("a").Where("b = ?", 1).Returning("id")
+
+	sql, args, err := b.ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "DELETE FROM a WHERE b = ? RETURNING id", sql)
+	assert.Equal(t, []interface{}{1}, args)
+
+	sql, _, err = b.Dialect(SQLServer).ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "DELETE FROM a OUTPUT DELETED.id WHERE b = ?", sql)
+}
+
+func TestDeleteBuilderUsing(t *testing.T) {
+	b := Delete("orders o").
+		Using("customers c").
+		Where("o.customer_id = c.id").
+		Where("c.deleted = ?", true)
+
+	sql, args, err := b.Dialect(PostgreSQL).ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "DELETE FROM orders o USING customers c WHERE o.customer_id = c.id AND c.deleted = ?", sql)
+	assert.Equal(t, []interface{}{true}, args)
+
+	sql, _, err = b.Dialect(MySQL).ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "DELETE o FROM orders o, customers c WHERE o.customer_id = c.id AND c.deleted = ?", sql)
+
+	_, _, err = b.Dialect(SQLite).ToSql()
+	assert.Error(t, err)
+}
+
+func TestDeleteBuilderJoin(t *testing.T) {
+	b := Delete("orders o").
+		LeftJoin("customers c ON c.id = o.customer_id").
+		Where("c.id IS NULL")
+
+	sql, _, err := b.ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "DELETE o FROM orders o LEFT JOIN customers c ON c.id = o.customer_id WHERE c.id IS NULL", sql)
+
+	sql, _, err = b.Targets("o", "c").Dialect(MySQL).ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "DELETE o, c FROM orders o LEFT JOIN customers c ON c.id = o.customer_id WHERE c.id IS NULL", sql)
+
+	sql, _, err = b.Returning("id").Dialect(SQLServer).ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "DELETE o OUTPUT DELETED.id FROM orders o LEFT JOIN customers c ON c.id = o.customer_id "+
+		"WHERE c.id IS NULL", sql)
+
+	_, _, err = b.Dialect(PostgreSQL).ToSql()
+	assert.Error(t, err)
+
+	_, _, err = b.Targets("o").Using("x").Dialect(PostgreSQL).ToSql()
+	assert.Error(t, err)
+
+	sql, _, err = Delete("orders o").
+		Using("customers c").
+		Join("regions r ON r.id = c.region_id").
+		Where("o.customer_id = c.id AND r.closed").
+		Dialect(PostgreSQL).
+		ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "DELETE FROM orders o USING customers c JOIN regions r ON r.id = c.region_id "+
+		"WHERE o.customer_id = c.id AND r.closed", sql)
+}
+
+func TestDeleteBuilderRequireWhere(t *testing.T) {
+	b := StatementBuilder.RequireWhere(true).Delete("a")
+
+	_, _, err := b.Where(Eq{}).ToSql()
+	assert.Equal(t, ErrMissingWhere, err)
+
+	sql, _, err := b.AllRows().ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "DELETE FROM a", sql)
+
+	sql, _, err = Delete("a").Condition().ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "DELETE FROM a", sql)
 }
@@ -0,0 +1,73 @@
+package squirrel
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/lann/builder"
+)
+
+// Dialect identifies the database a statement is rendered for.
+//
+// Statements built without a Dialect render the most portable form of each
+// clause.
+type Dialect string
+
+const (
+	// MySQL renders statements for MySQL and MariaDB.
+	MySQL Dialect = "mysql"
+
+	// PostgreSQL renders statements for PostgreSQL.
+	PostgreSQL Dialect = "postgres"
+
+	// SQLite renders statements for SQLite.
+	SQLite Dialect = "sqlite"
+
+	// SQLServer renders statements for Microsoft SQL Server.
+	SQLServer Dialect = "sqlserver"
+)
+
+// rowValues reports whether the dialect can compare row values, as in
+// (a, b) > (?, ?).
+func (d Dialect) rowValues() bool {
+	switch d {
+	case MySQL, PostgreSQL, SQLite:
+		return true
+	}
+	return false
+}
+
+// maxParams returns the maximum number of args a single statement can bind.
+// SQLite may be compiled with a higher limit than its historic default of 999.
+func (d Dialect) maxParams() int {
+	switch d {
+	case MySQL, PostgreSQL:
+		return 65535
+	case SQLite:
+		return 999
+	case SQLServer:
+		return 2100
+	}
+	return 0
+}
+
+// returningClause renders columns as a RETURNING clause, or for SQLServer as an
+// OUTPUT clause reading them from pseudoTable (INSERTED or DELETED).
+func (d Dialect) returningClause(columns []string, pseudoTable string) (string, error) {
+	switch d {
+	case MySQL:
+		return "", errors.New("mysql does not support RETURNING")
+	case SQLServer:
+		outputs := make([]string, len(columns))
+		for i, column := range columns {
+			outputs[i] = pseudoTable + "." + column
+		}
+		return "OUTPUT " + strings.Join(outputs, ", "), nil
+	}
+	return "RETURNING " + strings.Join(columns, ", "), nil
+}
+
+// Dialect sets the Dialect field for any child builders.
+func (b StatementBuilderType) Dialect(d Dialect) StatementBuilderType {
+	return builder.Set(b, "Dialect", d).(StatementBuilderType)
+}
@@ -44,6 +44,20 @@ func (es exprs) AppendToSql(w io.Writer, sep string, args []interface{}) ([]inte
 	return args, nil
 }
 
+// Default is the DEFAULT keyword, setting a column to its default value when
+// used in InsertBuilder.Values or UpdateBuilder.Set.
+//
+// Ex:
+//
+//	.Values("moe", Default)
+var Default = defaultKeyword{}
+
+type defaultKeyword struct{}
+
+func (defaultKeyword) ToSql() (string, []interface{}, error) {
+	return "DEFAULT", nil, nil
+}
+
 // aliasExpr helps to alias part of SQL query generated with underlying "expr"
 type aliasExpr struct {
 	expr  Sqlizer
@@ -8,7 +8,10 @@ import (
 )
 
 type whereData struct {
+	statementPolicies
 	PlaceholderFormat PlaceholderFormat
+	Dialect           Dialect
+	RunWith           BaseRunner
 	WhereParts        []Sqlizer
 	GroupBys          []string
 	HavingParts       []Sqlizer
@@ -20,12 +23,9 @@ type whereData struct {
 
 func (d *whereData) ToSql() (sqlStr string, args []interface{}, err error) {
 	sql := &bytes.Buffer{}
-	if len(d.WhereParts) > 0 {
-		sql.WriteString(" WHERE ")
-		args, err = appendToSql(d.WhereParts, sql, " AND ", args)
-		if err != nil {
-			return
-		}
+	args, _, err = appendWhereToSql(d.WhereParts, sql, args)
+	if err != nil {
+		return
 	}
 
 	if len(d.GroupBys) > 0 {
@@ -78,7 +78,7 @@ func init() {
 
 // PlaceholderFormat sets PlaceholderFormat (e.g. Question or Dollar) for the
 // query.
-func (b WhereBuilder) PlaceholderFormat(f PlaceholderFormat) WhereConditions {
+func (b WhereBuilder) PlaceholderFormat(f PlaceholderFormat) WhereBuilder {
 	return builder.Set(b, "PlaceholderFormat", f).(WhereBuilder)
 }
 
@@ -110,46 +110,46 @@ func (b WhereBuilder) ToSql() (string, []interface{}, error) {
 // are ANDed together.
 //
 // Where will panic if pred isn't any of the above types.
-func (b WhereBuilder) Where(pred interface{}, args ...interface{}) WhereConditions {
+func (b WhereBuilder) Where(pred interface{}, args ...interface{}) WhereBuilder {
 	return builder.Append(b, "WhereParts", newWherePart(pred, args...)).(WhereBuilder)
 }
 
 //Condition
-func (b WhereBuilder) Condition() WhereConditions {
+func (b WhereBuilder) Condition() WhereBuilder {
 	return builder.Append(b, "WhereParts", newWherePart("")).(WhereBuilder)
 }
 
 //expr
-func (b WhereBuilder) Expr(sql string, args ...interface{}) WhereConditions {
+func (b WhereBuilder) Expr(sql string, args ...interface{}) WhereBuilder {
 	return b.Where(Expr(sql, args))
 }
 
 //eq
-func (b WhereBuilder) Eq(column string, arg interface{}) WhereConditions {
+func (b WhereBuilder) Eq(column string, arg interface{}) WhereBuilder {
 	return b.Where(Eq{column: arg})
 }
 
-func (b WhereBuilder) NotEq(column string, arg interface{}) WhereConditions {
+func (b WhereBuilder) NotEq(column string, arg interface{}) WhereBuilder {
 	return b.Where(NotEq{column: arg})
 }
 
 //gt
-func (b WhereBuilder) Gt(column string, arg interface{}) WhereConditions {
+func (b WhereBuilder) Gt(column string, arg interface{}) WhereBuilder {
 	return b.Where(Gt{column: arg})
 }
 
 //gtOrEq
-func (b WhereBuilder) GtOrEq(column string, arg interface{}) WhereConditions {
+func (b WhereBuilder) GtOrEq(column string, arg interface{}) WhereBuilder {
 	return b.Where(GtOrEq{column: arg})
 }
 
 //lt
-func (b WhereBuilder) Lt(column string, arg interface{}) WhereConditions {
+func (b WhereBuilder) Lt(column string, arg interface{}) WhereBuilder {
 	return b.Where(Lt{column: arg})
 }
 
 //ltOrEq
-func (b WhereBuilder) LtOrEq(column string, arg interface{}) WhereConditions {
+func (b WhereBuilder) LtOrEq(column string, arg interface{}) WhereBuilder {
 	return b.Where(LtOrEq{column: arg})
 }
 
@@ -182,33 +182,85 @@ func (b WhereBuilder) Or(pred ...interface{}) WhereBuilder {
 }
 
 // GroupBy adds GROUP BY expressions to the query.
-func (b WhereBuilder) GroupBy(groupBys ...string) WhereConditions {
+func (b WhereBuilder) GroupBy(groupBys ...string) WhereBuilder {
 	return builder.Extend(b, "GroupBys", groupBys).(WhereBuilder)
 }
 
 // Having adds an expression to the HAVING clause of the query.
 //
 // See Where.
-func (b WhereBuilder) Having(pred interface{}, rest ...interface{}) WhereConditions {
+func (b WhereBuilder) Having(pred interface{}, rest ...interface{}) WhereBuilder {
 	return builder.Append(b, "HavingParts", newWherePart(pred, rest...)).(WhereBuilder)
 }
 
 // OrderBy adds ORDER BY expressions to the query.
-func (b WhereBuilder) OrderBy(orderBys ...string) WhereConditions {
+func (b WhereBuilder) OrderBy(orderBys ...string) WhereBuilder {
 	return builder.Extend(b, "OrderBys", orderBys).(WhereBuilder)
 }
 
 // Limit sets a LIMIT clause on the query.
-func (b WhereBuilder) Limit(limit int) WhereConditions {
+func (b WhereBuilder) Limit(limit int) WhereBuilder {
 	return builder.Set(b, "Limit", fmt.Sprintf("%d", limit)).(WhereBuilder)
 }
 
 // Offset sets a OFFSET clause on the query.
-func (b WhereBuilder) Offset(offset int) WhereConditions {
+func (b WhereBuilder) Offset(offset int) WhereBuilder {
 	return builder.Set(b, "Offset", fmt.Sprintf("%d", offset)).(WhereBuilder)
 }
 
 // Suffix adds an expression to the end of the query
-func (b WhereBuilder) Suffix(sql string, args ...interface{}) WhereConditions {
+func (b WhereBuilder) Suffix(sql string, args ...interface{}) WhereBuilder {
 	return builder.Append(b, "Suffixes", Expr(sql, args...)).(WhereBuilder)
 }
+
+func (b WhereBuilder) fragment() joinData {
+	d := builder.GetStruct(b).(whereData)
+	return joinData{
+		WhereParts:  d.WhereParts,
+		GroupBys:    d.GroupBys,
+		HavingParts: d.HavingParts,
+		OrderBys:    d.OrderBys,
+		Limit:       d.Limit,
+		Offset:      d.Offset,
+		Suffixes:    d.Suffixes,
+	}
+}
+
+// ConditionFragment is a reusable set of clauses built with the package level
+// Where, Condition or Join functions. It can be merged into a full statement
+// with the Merge method of SelectBuilder, UpdateBuilder or DeleteBuilder.
+type ConditionFragment interface {
+	Sqlizer
+	fragment() joinData
+}
+
+// mergeFragment folds the clauses of f into the statement builder b.
+// LIMIT and OFFSET of the fragment replace the ones already set on b.
+func mergeFragment(b interface{}, f ConditionFragment) interface{} {
+	d := f.fragment()
+	if len(d.Joins) > 0 {
+		b = builder.Extend(b, "Joins", d.Joins)
+	}
+	if len(d.WhereParts) > 0 {
+		b = builder.Extend(b, "WhereParts", d.WhereParts)
+	}
+	if len(d.GroupBys) > 0 {
+		b = builder.Extend(b, "GroupBys", d.GroupBys)
+	}
+	if len(d.HavingParts) > 0 {
+		b = builder.Extend(b, "HavingParts", d.HavingParts)
+	}
+	if len(d.OrderBys) > 0 {
+		b = builder.Extend(b, "OrderBys", d.OrderBys)
+	}
+	if len(d.Limit) > 0 {
+		b = builder.Set(b, "Limit", d.Limit)
+	}
+	if len(d.Offset) > 0 {
+		b = builder.Set(b, "Offset", d.Offset)
+	}
+	if len(d.Suffixes) > 0 {
+		b = builder.Extend(b, "Suffixes", d.Suffixes)
+	}
+	return b
+}
@@ -2,23 +2,41 @@ package squirrel
 
 import (
 	"bytes"
+	"context"
+	"database/sql"
 	"errors"
 	"fmt"
 	"io"
+	"reflect"
+	"sort"
 	"strings"
 
 	"github.com/lann/builder"
 )
 
 type insertData struct {
+	statementPolicies
 	PlaceholderFormat PlaceholderFormat
+	Dialect           Dialect
+	RunWith           BaseRunner
 	Prefixes          exprs
 	Options           []string
 	Into              string
 	Columns           []string
 	Values            [][]interface{}
 	Suffixes          exprs
-	Select            *SelectBuilder
+	Select            Sqlizer
+	ConflictTarget    []string
+	ConflictUpdates   []setClause
+	ConflictDoNothing bool
+	DuplicateKey      bool
+	DefaultValues     bool
+	Replace           bool
+	Ignore            bool
+	Returning         []string
+
+	// ctx is the context the statement is run with, for Audit.
+	ctx context.Context
 }
 
 func (d *insertData) ToSql() (sqlStr string, args []interface{}, err error) {
@@ -26,11 +44,50 @@ func (d *insertData) ToSql() (sqlStr string, args []interface{}, err error) {
 		err = errors.New("insert statements must specify a table")
 		return
 	}
-	if len(d.Values) == 0 && d.Select == nil {
+	if d.DefaultValues && len(d.Values) == 0 && d.Select == nil && len(d.Columns) > 0 {
+		err = errors.New("DefaultValues inserts cannot specify Columns")
+		return
+	}
+	if d.Audit != nil || d.Tenant != nil {
+		var filled *insertData
+		filled, err = d.withPolicyColumns()
+		if err != nil {
+			return
+		}
+		c := *filled
+		c.Audit, c.Tenant = nil, nil
+		return c.ToSql()
+	}
+	if len(d.Values) == 0 && d.Select == nil && !d.DefaultValues {
 		err = errors.New("insert statements must have at least one set of values or select clause")
 		return
 	}
 
+	if d.Replace && d.Ignore {
+		err = errors.New("insert statements cannot both Replace and Ignore")
+		return
+	}
+	if d.Replace && (len(d.ConflictUpdates) > 0 || d.ConflictDoNothing ||
+		(d.Dialect != PostgreSQL && (d.DuplicateKey || len(d.ConflictTarget) > 0))) {
+		err = errors.New("Replace cannot be combined with ON CONFLICT or ON DUPLICATE KEY UPDATE")
+		return
+	}
+	if (d.Replace || d.Ignore) && d.Dialect == SQLServer {
+		err = errors.New("sqlserver does not support REPLACE or IGNORE, use a MERGE statement")
+		return
+	}
+	if (d.Replace || d.Ignore) && d.Dialect == PostgreSQL {
+		return d.conflictInsert()
+	}
+
+	var returning string
+	if len(d.Returning) > 0 {
+		returning, err = d.Dialect.returningClause(d.Returning, "INSERTED")
+		if err != nil {
+			return
+		}
+	}
+
 	sql := &bytes.Buffer{}
 
 	if len(d.Prefixes) > 0 {
@@ -38,7 +95,18 @@ func (d *insertData) ToSql() (sqlStr string, args []interface{}, err error) {
 		sql.WriteString(" ")
 	}
 
-	sql.WriteString("INSERT ")
+	switch {
+	case d.Replace && d.Dialect == SQLite:
+		sql.WriteString("INSERT OR REPLACE ")
+	case d.Replace:
+		sql.WriteString("REPLACE ")
+	case d.Ignore && d.Dialect == SQLite:
+		sql.WriteString("INSERT OR IGNORE ")
+	case d.Ignore:
+		sql.WriteString("INSERT IGNORE ")
+	default:
+		sql.WriteString("INSERT ")
+	}
 
 	if len(d.Options) > 0 {
 		sql.WriteString(strings.Join(d.Options, " "))
@@ -46,24 +114,48 @@ func (d *insertData) ToSql() (sqlStr string, args []interface{}, err error) {
 	}
 
 	sql.WriteString("INTO ")
-	sql.WriteString(d.Into)
+	sql.WriteString(d.resolveTable(d.Into, false))
 	sql.WriteString(" ")
 
 	if len(d.Columns) > 0 {
 		sql.WriteString("(")
 		sql.WriteString(strings.Join(d.Columns, ","))
 		sql.WriteString(") ")
+	} else if d.DefaultValues && d.Dialect == MySQL {
+		sql.WriteString("() ")
 	}
 
-	if d.Select != nil {
+	if len(returning) > 0 && d.Dialect == SQLServer {
+		sql.WriteString(returning)
+		sql.WriteString(" ")
+	}
+
+	switch {
+	case d.Select != nil:
 		args, err = d.appendSelectToSQL(sql, args)
-	} else {
+	case d.DefaultValues && len(d.Values) == 0:
+		if d.Dialect == MySQL {
+			sql.WriteString("VALUES ()")
+		} else {
+			sql.WriteString("DEFAULT VALUES")
+		}
+	default:
 		args, err = d.appendValuesToSQL(sql, args)
 	}
 	if err != nil {
 		return
 	}
 
+	args, err = d.appendConflictToSQL(sql, args)
+	if err != nil {
+		return
+	}
+
+	if len(returning) > 0 && d.Dialect != SQLServer {
+		sql.WriteString(" ")
+		sql.WriteString(returning)
+	}
+
 	if len(d.Suffixes) > 0 {
 		sql.WriteString(" ")
 		args, _ = d.Suffixes.AppendToSql(sql, " ", args)
@@ -73,32 +165,323 @@ func (d *insertData) ToSql() (sqlStr string, args []interface{}, err error) {
 	return
 }
 
+// withContext returns a copy of d run with ctx.
+func (d insertData) withContext(ctx context.Context) *insertData {
+	d.ctx = ctx
+	return &d
+}
+
+// withPolicyColumns returns d with the tenant and audit columns it does not
+// set yet added to its Columns and every row, and with the update audit
+// columns added to the SET clause of an upsert. A DefaultValues insert becomes
+// a row of these columns. Inserts from a Select or of Values without Columns
+// cannot be matched to columns: they are left without audit columns, and must
+// set the tenant column themselves when inserting into a tenant scoped table.
+func (d *insertData) withPolicyColumns() (*insertData, error) {
+	filled := *d
+	if d.Audit != nil && len(d.ConflictUpdates) > 0 {
+		updated := make(map[string]bool, len(d.ConflictUpdates))
+		for _, c := range d.ConflictUpdates {
+			updated[c.column] = true
+		}
+		columns, values := d.Audit.columns(d.ctx, false, func(column string) bool { return updated[column] })
+		filled.ConflictUpdates = d.ConflictUpdates[:len(d.ConflictUpdates):len(d.ConflictUpdates)]
+		for i, column := range columns {
+			filled.ConflictUpdates = append(filled.ConflictUpdates, setClause{column: column, value: values[i]})
+		}
+	}
+
+	set := make(map[string]bool, len(d.Columns))
+	for _, column := range d.Columns {
+		set[column] = true
+	}
+	tenant := d.Tenant != nil && appliesTo(d.Tenant.tables, d.Into) && !set[d.Tenant.column]
+
+	defaults := d.DefaultValues && len(d.Values) == 0 && d.Select == nil && len(d.Columns) == 0
+	if d.Select != nil || (len(d.Columns) == 0 && !defaults) {
+		if tenant {
+			return nil, fmt.Errorf("insert into tenant scoped table %s from a Select or without Columns must set %s",
+				d.Into, d.Tenant.column)
+		}
+		return &filled, nil
+	}
+	rows := [][]interface{}{{}}
+	if !defaults {
+		var err error
+		if rows, err = d.rows(); err != nil {
+			return nil, err
+		}
+	}
+	if d.Tenant != nil && appliesTo(d.Tenant.tables, d.Into) && set[d.Tenant.column] {
+		if err := d.checkTenantValues(rows); err != nil {
+			return nil, err
+		}
+	}
+
+	var (
+		columns []string
+		values  []interface{}
+	)
+	if tenant {
+		columns = append(columns, d.Tenant.column)
+		values = append(values, d.Tenant.id)
+	}
+	if d.Audit != nil {
+		auditColumns, auditValues := d.Audit.columns(d.ctx, true, func(column string) bool { return set[column] })
+		columns = append(columns, auditColumns...)
+		values = append(values, auditValues...)
+	}
+	if len(columns) == 0 {
+		return &filled, nil
+	}
+
+	filled.DefaultValues = false
+	filled.Columns = append(d.Columns[:len(d.Columns):len(d.Columns)], columns...)
+	filled.Values = make([][]interface{}, len(rows))
+	for i, row := range rows {
+		filled.Values[i] = append(row[:len(row):len(row)], values...)
+	}
+	return &filled, nil
+}
+
+// checkTenantValues returns an error if a row of rows sets the tenant column
+// to another value than the tenant of the statement.
+func (d *insertData) checkTenantValues(rows [][]interface{}) error {
+	i := 0
+	for d.Columns[i] != d.Tenant.column {
+		i++
+	}
+	for r, row := range rows {
+		if !d.Tenant.owns(row[i]) {
+			return fmt.Errorf("insert row %d sets %s to %v, not to the tenant %v", r, d.Tenant.column, row[i], d.Tenant.id)
+		}
+	}
+	return nil
+}
+
+// batches splits the rows of the query into queries binding at most maxParams
+// args each. The args of the prefixes, suffixes and ON CONFLICT clause count
+// towards every batch.
+func (d *insertData) batches(ctx context.Context, b InsertBuilder, maxParams int) ([]InsertBuilder, error) {
+	if maxParams <= 0 {
+		maxParams = d.Dialect.maxParams()
+	}
+	if maxParams <= 0 {
+		return nil, errors.New("insert batches need a parameter limit or a Dialect")
+	}
+
+	if d.Audit != nil || d.Tenant != nil {
+		filled, err := d.withContext(ctx).withPolicyColumns()
+		if err != nil {
+			return nil, err
+		}
+		d = filled
+		b = builder.Set(b, "Columns", d.Columns).(InsertBuilder)
+	}
+
+	_, args, err := d.ToSql()
+	if err != nil {
+		return nil, err
+	}
+	if d.Select != nil {
+		return []InsertBuilder{b}, nil
+	}
+
+	rows, err := d.rows()
+	if err != nil {
+		return nil, err
+	}
+	rowParams := make([]int, len(rows))
+	overhead := len(args)
+	for i, row := range rows {
+		_, rowArgs := rowToSql(row)
+		rowParams[i] = len(rowArgs)
+		overhead -= len(rowArgs)
+	}
+
+	var batches []InsertBuilder
+	start, params := 0, overhead
+	for i, n := range rowParams {
+		if overhead+n > maxParams {
+			return nil, fmt.Errorf(
+				"insert row %d needs %d args, more than the limit of %d per statement",
+				i, overhead+n, maxParams)
+		}
+		if params+n > maxParams {
+			batches = append(batches, builder.Set(b, "Values", d.Values[start:i]).(InsertBuilder))
+			start, params = i, overhead
+		}
+		params += n
+	}
+	return append(batches, builder.Set(b, "Values", d.Values[start:]).(InsertBuilder)), nil
+}
+
+func (d *insertData) Exec() (sql.Result, error) {
+	if d.RunWith == nil {
+		return nil, RunnerNotSet
+	}
+	return ExecWith(d.RunWith, d)
+}
+
+func (d *insertData) Query() (*sql.Rows, error) {
+	if d.RunWith == nil {
+		return nil, RunnerNotSet
+	}
+	return QueryWith(d.RunWith, d)
+}
+
+func (d *insertData) QueryRow() RowScanner {
+	if d.RunWith == nil {
+		return &Row{err: RunnerNotSet}
+	}
+	queryRower, ok := d.RunWith.(QueryRower)
+	if !ok {
+		return &Row{err: RunnerNotQueryRunner}
+	}
+	return QueryRowWith(queryRower, d)
+}
+
+func (d *insertData) ExecContext(ctx context.Context) (sql.Result, error) {
+	if d.RunWith == nil {
+		return nil, RunnerNotSet
+	}
+	ctxRunner, ok := d.RunWith.(ExecerContext)
+	if !ok {
+		return nil, NoContextSupport
+	}
+	return ExecContextWith(ctx, ctxRunner, d.withContext(ctx))
+}
+
+func (d *insertData) QueryContext(ctx context.Context) (*sql.Rows, error) {
+	if d.RunWith == nil {
+		return nil, RunnerNotSet
+	}
+	ctxRunner, ok := d.RunWith.(QueryerContext)
+	if !ok {
+		return nil, NoContextSupport
+	}
+	return QueryContextWith(ctx, ctxRunner, d.withContext(ctx))
+}
+
+func (d *insertData) QueryRowContext(ctx context.Context) RowScanner {
+	if d.RunWith == nil {
+		return &Row{err: RunnerNotSet}
+	}
+	queryRower, ok := d.RunWith.(QueryRowerContext)
+	if !ok {
+		if _, ok := d.RunWith.(QueryerContext); !ok {
+			return &Row{err: RunnerNotQueryRunner}
+		}
+		return &Row{err: NoContextSupport}
+	}
+	return QueryRowContextWith(ctx, queryRower, d.withContext(ctx))
+}
+
 func (d *insertData) appendValuesToSQL(w io.Writer, args []interface{}) ([]interface{}, error) {
 	if len(d.Values) == 0 {
 		return args, errors.New("values for insert statements are not set")
 	}
 
+	rows, err := d.rows()
+	if err != nil {
+		return args, err
+	}
+
 	io.WriteString(w, "VALUES ")
 
-	valuesStrings := make([]string, len(d.Values))
+	valuesStrings := make([]string, len(rows))
+	for r, row := range rows {
+		rowSql, rowArgs := rowToSql(row)
+		valuesStrings[r] = rowSql
+		args = append(args, rowArgs...)
+	}
+
+	io.WriteString(w, strings.Join(valuesStrings, ","))
+
+	return args, nil
+}
+
+// rows returns the rows of values with the rows added by ValuesMap laid out
+// in the order of Columns. Every row must have one value per column, or as
+// many values as the first row without Columns.
+func (d *insertData) rows() ([][]interface{}, error) {
+	rows := make([][]interface{}, len(d.Values))
 	for r, row := range d.Values {
-		valueStrings := make([]string, len(row))
-		for v, val := range row {
-			e, isExpr := val.(expr)
-			if isExpr {
-				valueStrings[v] = e.sql
-				args = append(args, e.args...)
-			} else {
-				valueStrings[v] = "?"
-				args = append(args, val)
+		if len(row) == 1 {
+			if m, ok := row[0].(valuesMap); ok {
+				var err error
+				if row, err = m.row(d.Columns); err != nil {
+					return nil, fmt.Errorf("insert row %d: %v", r, err)
+				}
 			}
 		}
-		valuesStrings[r] = fmt.Sprintf("(%s)", strings.Join(valueStrings, ","))
+		rows[r] = row
 	}
 
-	io.WriteString(w, strings.Join(valuesStrings, ","))
+	expected := len(d.Columns)
+	if expected == 0 && len(rows) > 0 {
+		expected = len(rows[0])
+	}
+	for r, row := range rows {
+		if len(row) != expected {
+			return nil, fmt.Errorf("insert row %d has %d values but expected %d", r, len(row), expected)
+		}
+		if d.Dialect != SQLite {
+			continue
+		}
+		for _, value := range row {
+			if value == Default {
+				return nil, fmt.Errorf("insert row %d: sqlite does not support DEFAULT in VALUES", r)
+			}
+		}
+	}
+	return rows, nil
+}
 
-	return args, nil
+// valuesMap is a row of values keyed by column, added by ValuesMap.
+type valuesMap map[string]interface{}
+
+// row lays m out in the order of columns, filling the missing ones with
+// Default.
+func (m valuesMap) row(columns []string) ([]interface{}, error) {
+	if len(columns) == 0 {
+		return nil, errors.New("ValuesMap needs Columns")
+	}
+	known := make(map[string]bool, len(columns))
+	row := make([]interface{}, len(columns))
+	for i, column := range columns {
+		known[column] = true
+		value, ok := m[column]
+		if !ok {
+			value = Default
+		}
+		row[i] = value
+	}
+	for column := range m {
+		if !known[column] {
+			return nil, fmt.Errorf("ValuesMap column %q is not in Columns", column)
+		}
+	}
+	return row, nil
+}
+
+// rowToSql renders a single row of values as "(?,?,...)".
+func rowToSql(row []interface{}) (string, []interface{}) {
+	var args []interface{}
+	valueStrings := make([]string, len(row))
+	for v, val := range row {
+		e, isExpr := val.(expr)
+		if isExpr {
+			valueStrings[v] = e.sql
+			args = append(args, e.args...)
+		} else if val == Default {
+			valueStrings[v] = "DEFAULT"
+		} else {
+			valueStrings[v] = "?"
+			args = append(args, val)
+		}
+	}
+	return fmt.Sprintf("(%s)", strings.Join(valueStrings, ",")), args
 }
 
 func (d *insertData) appendSelectToSQL(w io.Writer, args []interface{}) ([]interface{}, error) {
@@ -106,10 +489,13 @@ func (d *insertData) appendSelectToSQL(w io.Writer, args []interface{}) ([]inter
 		return args, errors.New("select clause for insert statements are not set")
 	}
 
-	selectClause, sArgs, err := d.Select.ToSql()
+	selectClause, sArgs, err := questionPlaceholders(d.Select).ToSql()
 	if err != nil {
 		return args, err
 	}
+	if n, ok := selectColumnCount(d.Select); ok && len(d.Columns) > 0 && n != len(d.Columns) {
+		return args, fmt.Errorf("insert has %d columns but its select returns %d", len(d.Columns), n)
+	}
 
 	io.WriteString(w, selectClause)
 	args = append(args, sArgs...)
@@ -117,6 +503,155 @@ func (d *insertData) appendSelectToSQL(w io.Writer, args []interface{}) ([]inter
 	return args, nil
 }
 
+// conflictInsert renders Replace and Ignore as an ON CONFLICT clause: Ignore
+// does nothing and Replace updates every inserted column but the conflict
+// target.
+func (d *insertData) conflictInsert() (string, []interface{}, error) {
+	c := *d
+	c.Replace, c.Ignore = false, false
+	if d.Ignore {
+		if len(d.ConflictUpdates) > 0 {
+			return "", nil, errors.New("Ignore cannot be combined with DoUpdateSet")
+		}
+		c.ConflictDoNothing = true
+		return c.ToSql()
+	}
+
+	if len(d.ConflictTarget) == 0 {
+		return "", nil, errors.New("postgres Replace must specify the conflict target with OnConflict")
+	}
+	if len(d.Columns) == 0 {
+		return "", nil, errors.New("postgres Replace must specify Columns")
+	}
+	target := make(map[string]bool, len(d.ConflictTarget))
+	for _, column := range d.ConflictTarget {
+		target[column] = true
+	}
+	c.ConflictUpdates = nil
+	for _, column := range d.Columns {
+		if !target[column] {
+			c.ConflictUpdates = append(c.ConflictUpdates, setClause{column: column, value: Excluded(column)})
+		}
+	}
+	if len(c.ConflictUpdates) == 0 {
+		c.ConflictDoNothing = true
+	}
+	return c.ToSql()
+}
+
+func (d *insertData) appendConflictToSQL(w io.Writer, args []interface{}) ([]interface{}, error) {
+	if len(d.ConflictTarget) == 0 && len(d.ConflictUpdates) == 0 && !d.ConflictDoNothing {
+		return args, nil
+	}
+
+	duplicateKey := d.Dialect == MySQL || (d.Dialect == "" && d.DuplicateKey)
+	switch {
+	case d.Dialect == SQLServer:
+		return args, errors.New("sqlserver does not support ON CONFLICT, use a MERGE statement")
+	case duplicateKey && d.ConflictDoNothing:
+		return args, errors.New("ON DUPLICATE KEY UPDATE does not support DO NOTHING")
+	case duplicateKey && len(d.ConflictUpdates) == 0:
+		return args, errors.New("ON DUPLICATE KEY UPDATE must have at least one Set clause")
+	case !duplicateKey && !d.ConflictDoNothing && len(d.ConflictUpdates) == 0:
+		return args, errors.New("ON CONFLICT must be followed by DoUpdateSet or DoNothing")
+	case !duplicateKey && !d.ConflictDoNothing && len(d.ConflictTarget) == 0:
+		return args, errors.New("ON CONFLICT DO UPDATE must specify the conflict target columns")
+	}
+
+	if duplicateKey {
+		io.WriteString(w, " ON DUPLICATE KEY UPDATE ")
+	} else {
+		io.WriteString(w, " ON CONFLICT ")
+		if len(d.ConflictTarget) > 0 {
+			fmt.Fprintf(w, "(%s) ", strings.Join(d.ConflictTarget, ","))
+		}
+		if d.ConflictDoNothing {
+			io.WriteString(w, "DO NOTHING")
+			return args, nil
+		}
+		io.WriteString(w, "DO UPDATE SET ")
+	}
+
+	for i, set := range d.ConflictUpdates {
+		if i > 0 {
+			io.WriteString(w, ", ")
+		}
+		if v, ok := set.value.(excluded); ok {
+			if duplicateKey {
+				set.value = Expr(fmt.Sprintf("VALUES(%s)", string(v)))
+			} else {
+				set.value = Expr(fmt.Sprintf("EXCLUDED.%s", string(v)))
+			}
+		}
+		var err error
+		args, err = set.appendToSql(w, args)
+		if err != nil {
+			return args, err
+		}
+	}
+
+	return args, nil
+}
+
+// questionPlaceholders returns s rendering "?" placeholders, for builders
+// nested in a statement that numbers the placeholders itself.
+func questionPlaceholders(s Sqlizer) Sqlizer {
+	switch b := s.(type) {
+	case SelectBuilder:
+		return b.PlaceholderFormat(Question)
+	case UpdateBuilder:
+		return b.PlaceholderFormat(Question)
+	case DeleteBuilder:
+		return b.PlaceholderFormat(Question)
+	case WhereBuilder:
+		return b.PlaceholderFormat(Question)
+	}
+	return s
+}
+
+// selectColumnCount returns the number of result columns of s when s is a
+// SelectBuilder whose columns can be counted without running it.
+func selectColumnCount(s Sqlizer) (int, bool) {
+	b, ok := s.(SelectBuilder)
+	if !ok {
+		return 0, false
+	}
+	data := builder.GetStruct(b).(selectData)
+	n := 0
+	for _, column := range data.Columns {
+		sql, _, err := column.ToSql()
+		if err != nil {
+			return 0, false
+		}
+		for _, item := range splitTopLevel(sql) {
+			item = strings.TrimSpace(item)
+			if item == "*" || strings.HasSuffix(item, ".*") {
+				return 0, false
+			}
+			n++
+		}
+	}
+	return n, n > 0
+}
+
+// excluded is the value a conflicting insert proposed for a column.
+type excluded string
+
+// Excluded refers to the value column would have been inserted with, for use
+// in DoUpdateSet and OnDuplicateKeyUpdate. It renders as EXCLUDED.column, or
+// as VALUES(column) in ON DUPLICATE KEY UPDATE.
+//
+// Ex:
+//
+//	.OnConflict("id").DoUpdateSet("name", Excluded("name"))
+func Excluded(column string) excluded {
+	return excluded(column)
+}
+
+func (e excluded) ToSql() (string, []interface{}, error) {
+	return "EXCLUDED." + string(e), nil, nil
+}
+
 // Builder
 
 // InsertBuilder builds SQL INSERT statements.
@@ -130,10 +665,108 @@ func init() {
 
 // PlaceholderFormat sets PlaceholderFormat (e.g. Question or Dollar) for the
 // query.
-func (b InsertBuilder) PlaceholderFormat(f PlaceholderFormat) InsertCondition {
+func (b InsertBuilder) PlaceholderFormat(f PlaceholderFormat) InsertBuilder {
 	return builder.Set(b, "PlaceholderFormat", f).(InsertBuilder)
 }
 
+// Dialect sets the Dialect (e.g. MySQL or PostgreSQL) the query is rendered
+// for.
+func (b InsertBuilder) Dialect(d Dialect) InsertBuilder {
+	return builder.Set(b, "Dialect", d).(InsertBuilder)
+}
+
+// Runner methods
+
+// RunWith sets a Runner (like database/sql.DB) to be used with e.g. Exec.
+// For most cases runner will be a database connection.
+//
+// Internally we use this to mock out the database connection for testing.
+func (b InsertBuilder) RunWith(runner BaseRunner) InsertBuilder {
+	return setRunWith(b, runner).(InsertBuilder)
+}
+
+// Exec builds and Execs the query with the Runner set by RunWith.
+func (b InsertBuilder) Exec() (sql.Result, error) {
+	data := builder.GetStruct(b).(insertData)
+	return data.Exec()
+}
+
+// Query builds and Querys the query with the Runner set by RunWith.
+func (b InsertBuilder) Query() (*sql.Rows, error) {
+	data := builder.GetStruct(b).(insertData)
+	return data.Query()
+}
+
+// QueryRow builds and QueryRows the query with the Runner set by RunWith.
+func (b InsertBuilder) QueryRow() RowScanner {
+	data := builder.GetStruct(b).(insertData)
+	return data.QueryRow()
+}
+
+// Scan is a shortcut for QueryRow().Scan.
+func (b InsertBuilder) Scan(dest ...interface{}) error {
+	return b.QueryRow().Scan(dest...)
+}
+
+// ExecContext builds and ExecContexts the query with the Runner set by RunWith.
+func (b InsertBuilder) ExecContext(ctx context.Context) (sql.Result, error) {
+	data := builder.GetStruct(b).(insertData)
+	return data.ExecContext(ctx)
+}
+
+// QueryContext builds and QueryContexts the query with the Runner set by
+// RunWith.
+func (b InsertBuilder) QueryContext(ctx context.Context) (*sql.Rows, error) {
+	data := builder.GetStruct(b).(insertData)
+	return data.QueryContext(ctx)
+}
+
+// QueryRowContext builds and QueryRowContexts the query with the Runner set by
+// RunWith.
+func (b InsertBuilder) QueryRowContext(ctx context.Context) RowScanner {
+	data := builder.GetStruct(b).(insertData)
+	return data.QueryRowContext(ctx)
+}
+
+// ScanContext is a shortcut for QueryRowContext().Scan.
+func (b InsertBuilder) ScanContext(ctx context.Context, dest ...interface{}) error {
+	return b.QueryRowContext(ctx).Scan(dest...)
+}
+
+// ExecBatches Execs the query in batches as split by Batches, with the Runner
+// set by RunWith, and returns the total number of rows affected.
+//
+// The batches are not run in a transaction unless the Runner is a *sql.Tx.
+func (b InsertBuilder) ExecBatches(maxParams int) (int64, error) {
+	return b.ExecBatchesContext(context.Background(), maxParams)
+}
+
+// ExecBatchesContext Execs the query in batches as split by Batches, with the
+// Runner set by RunWith, and returns the total number of rows affected.
+//
+// The batches are not run in a transaction unless the Runner is a *sql.Tx.
+func (b InsertBuilder) ExecBatchesContext(ctx context.Context, maxParams int) (int64, error) {
+	data := builder.GetStruct(b).(insertData)
+	batches, err := data.batches(ctx, b, maxParams)
+	if err != nil {
+		return 0, err
+	}
+
+	var total int64
+	for _, batch := range batches {
+		res, err := batch.ExecContext(ctx)
+		if err != nil {
+			return total, err
+		}
+		n, err := res.RowsAffected()
+		if err != nil {
+			return total, err
+		}
+		total += n
+	}
+	return total, nil
+}
+
 // SQL methods
 
 // ToSql builds the query into a SQL string and bound args.
@@ -143,38 +776,60 @@ func (b InsertBuilder) ToSql() (string, []interface{}, error) {
 }
 
 // Prefix adds an expression to the beginning of the query
-func (b InsertBuilder) Prefix(sql string, args ...interface{}) InsertCondition {
+func (b InsertBuilder) Prefix(sql string, args ...interface{}) InsertBuilder {
 	return builder.Append(b, "Prefixes", Expr(sql, args...)).(InsertBuilder)
 }
 
 // Options adds keyword options before the INTO clause of the query.
-func (b InsertBuilder) Options(options ...string) InsertCondition {
+func (b InsertBuilder) Options(options ...string) InsertBuilder {
 	return builder.Extend(b, "Options", options).(InsertBuilder)
 }
 
 // Into sets the INTO clause of the query.
-func (b InsertBuilder) Into(from string) InsertCondition {
+func (b InsertBuilder) Into(from string) InsertBuilder {
 	return builder.Set(b, "Into", from).(InsertBuilder)
 }
 
 // Columns adds insert columns to the query.
-func (b InsertBuilder) Columns(columns ...string) InsertCondition {
+func (b InsertBuilder) Columns(columns ...string) InsertBuilder {
 	return builder.Extend(b, "Columns", columns).(InsertBuilder)
 }
 
 // Values adds a single row's values to the query.
-func (b InsertBuilder) Values(values ...interface{}) InsertCondition {
+func (b InsertBuilder) Values(values ...interface{}) InsertBuilder {
 	return builder.Append(b, "Values", values).(InsertBuilder)
 }
 
+// Ignore skips rows that conflict with existing ones: "INSERT IGNORE" for
+// MySQL, "INSERT OR IGNORE" for SQLite and "ON CONFLICT DO NOTHING" for
+// PostgreSQL.
+func (b InsertBuilder) Ignore() InsertBuilder {
+	return builder.Set(b, "Ignore", true).(InsertBuilder)
+}
+
+// DefaultValues inserts a single row of column defaults, as in
+// "INSERT INTO t DEFAULT VALUES", or "INSERT INTO t () VALUES ()" for MySQL.
+// It is ignored when Values or Select are used, and cannot be combined with
+// Columns otherwise.
+func (b InsertBuilder) DefaultValues() InsertBuilder {
+	return builder.Set(b, "DefaultValues", true).(InsertBuilder)
+}
+
+// ValuesMap adds a single row of values keyed by column. Columns missing from
+// values are set to DEFAULT. The row is laid out in the order of Columns when
+// the query is built.
+func (b InsertBuilder) ValuesMap(values map[string]interface{}) InsertBuilder {
+	return builder.Append(b, "Values", []interface{}{valuesMap(values)}).(InsertBuilder)
+}
+
 // Suffix adds an expression to the end of the query
-func (b InsertBuilder) Suffix(sql string, args ...interface{}) InsertCondition {
+func (b InsertBuilder) Suffix(sql string, args ...interface{}) InsertBuilder {
 	return builder.Append(b, "Suffixes", Expr(sql, args...)).(InsertBuilder)
 }
 
 // SetMap set columns and values for insert builder from a map of column name and value
 // note that it will reset all previous columns and values was set if any
-func (b InsertBuilder) SetMap(clauses map[string]interface{}) InsertCondition {
+func (b InsertBuilder) SetMap(clauses map[string]interface{}) InsertBuilder {
 	cols := make([]string, 0, len(clauses))
 	vals := make([]interface{}, 0, len(clauses))
 	for col, val := range clauses {
@@ -187,8 +842,175 @@ func (b InsertBuilder) SetMap(clauses map[string]interface{}) InsertCondition {
 	return b
 }
 
-// Select set Select clause for insert query
+// Select sets the query whose rows are inserted, such as a SelectBuilder,
+// a union or an Expr. Its placeholders are numbered along with the rest of the
+// statement.
 // If Values and Select are used, then Select has higher priority
-func (b InsertBuilder) Select(sb SelectCondition) InsertCondition {
-	return builder.Set(b, "Select", &sb).(InsertBuilder)
+func (b InsertBuilder) Select(source Sqlizer) InsertBuilder {
+	return builder.Set(b, "Select", source).(InsertBuilder)
+}
+
+// Batches splits the rows added with Values into queries which bind at most
+// maxParams args each, keeping the prefixes, options, suffixes and ON CONFLICT
+// clause on every query. A maxParams of 0 uses the limit of the Dialect.
+//
+// Queries inserting from a Select are returned as a single batch.
+func (b InsertBuilder) Batches(maxParams int) ([]Sqlizer, error) {
+	data := builder.GetStruct(b).(insertData)
+	batches, err := data.batches(context.Background(), b, maxParams)
+	if err != nil {
+		return nil, err
+	}
+	sqlizers := make([]Sqlizer, len(batches))
+	for i, batch := range batches {
+		sqlizers[i] = batch
+	}
+	return sqlizers, nil
+}
+
+// Returning adds columns of the inserted rows to be returned by the query, in a
+// RETURNING clause or an OUTPUT clause for SQLServer. Read them with QueryRow,
+// e.g. to get generated ids:
+//
+//	Insert("users").Columns("name").Values("moe").Returning("id").QueryRow().Scan(&id)
+func (b InsertBuilder) Returning(columns ...string) InsertBuilder {
+	return builder.Extend(b, "Returning", columns).(InsertBuilder)
+}
+
+// OnConflict adds an ON CONFLICT clause for the given unique columns to the
+// query. It must be followed by DoUpdateSet or DoNothing.
+//
+// The MySQL Dialect renders the clause as ON DUPLICATE KEY UPDATE, which
+// ignores the columns.
+func (b InsertBuilder) OnConflict(columns ...string) InsertBuilder {
+	return builder.Set(b, "ConflictTarget", columns).(InsertBuilder)
+}
+
+// DoUpdateSet adds a SET clause to the ON CONFLICT DO UPDATE part of the query.
+// Use Excluded to refer to the values of the conflicting row.
+func (b InsertBuilder) DoUpdateSet(column string, value interface{}) InsertBuilder {
+	return builder.Append(b, "ConflictUpdates", setClause{column: column, value: value}).(InsertBuilder)
+}
+
+// DoUpdateSetMap is a convenience method which calls .DoUpdateSet for each
+// key/value pair in clauses.
+func (b InsertBuilder) DoUpdateSetMap(clauses map[string]interface{}) InsertBuilder {
+	keys := make([]string, 0, len(clauses))
+	for key := range clauses {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		b = b.DoUpdateSet(key, clauses[key])
+	}
+	return b
+}
+
+// DoNothing makes the query skip rows conflicting with the OnConflict columns.
+func (b InsertBuilder) DoNothing() InsertBuilder {
+	return builder.Set(b, "ConflictDoNothing", true).(InsertBuilder)
+}
+
+// OnDuplicateKeyUpdate adds an ON DUPLICATE KEY UPDATE clause to the query,
+// setting the columns in clauses. Use Excluded to refer to the values of the
+// conflicting row.
+//
+// The PostgreSQL and SQLite Dialects render the clause as ON CONFLICT DO
+// UPDATE, which also needs the conflict columns set with OnConflict.
+func (b InsertBuilder) OnDuplicateKeyUpdate(clauses map[string]interface{}) InsertBuilder {
+	b = builder.Set(b, "DuplicateKey", true).(InsertBuilder)
+	return b.DoUpdateSetMap(clauses)
+}
+
+// SetStruct sets the columns and values of the query from the db tagged fields
+// of v, a struct or a pointer to one, in declaration order. Like SetMap it
+// replaces all previously set columns and values.
+//
+// Fields tagged readonly are skipped, as are fields tagged omitempty holding
+// their zero value. See ValuesStructs for inserting many rows.
+//
+// SetStruct panics if v is not a struct or a pointer to one.
+func (b InsertBuilder) SetStruct(v interface{}) InsertBuilder {
+	columns := structColumns(v, structOptions{})
+	cols := make([]string, len(columns))
+	vals := make([]interface{}, len(columns))
+	for i, c := range columns {
+		cols[i] = c.column
+		vals[i] = c.value
+	}
+
+	b = builder.Set(b, "Columns", cols).(InsertBuilder)
+	b = builder.Set(b, "Values", [][]interface{}{vals}).(InsertBuilder)
+	return b
+}
+
+// ValuesStructs sets the columns of the query from the db tagged fields of the
+// element type of structs, a slice of structs or of pointers to structs, and
+// adds one row of values per element. It replaces all previously set columns
+// and values.
+//
+// Fields tagged readonly are skipped. Fields tagged omitempty are skipped only
+// when they hold their zero value in every element, so that all rows have the
+// same columns.
+//
+// ValuesStructs panics if structs is not a slice of structs, or of non-nil
+// pointers to structs.
+func (b InsertBuilder) ValuesStructs(structs interface{}) InsertBuilder {
+	slice := reflect.ValueOf(structs)
+	if slice.Kind() != reflect.Slice {
+		panic(fmt.Sprintf("expected a slice of structs, not %T", structs))
+	}
+	elemType := slice.Type().Elem()
+	if elemType.Kind() == reflect.Ptr {
+		elemType = elemType.Elem()
+	}
+	if elemType.Kind() != reflect.Struct {
+		panic(fmt.Sprintf("expected a slice of structs, not %T", structs))
+	}
+
+	elems := make([]reflect.Value, slice.Len())
+	for i := range elems {
+		elem := slice.Index(i)
+		if elem.Kind() == reflect.Ptr && elem.IsNil() {
+			panic(fmt.Sprintf("ValuesStructs element %d is a nil %s", i, elem.Type()))
+		}
+		elems[i] = structValue(elem.Interface())
+	}
+
+	var (
+		cols   []string
+		fields []structField
+	)
+	for _, f := range structFields(elemType) {
+		if f.readOnly || f.joined {
+			continue
+		}
+		if f.omitEmpty {
+			empty := true
+			for _, elem := range elems {
+				field, ok := fieldValue(elem, f.index)
+				empty = empty && (!ok || field.IsZero())
+			}
+			if empty {
+				continue
+			}
+		}
+		cols = append(cols, f.column)
+		fields = append(fields, f)
+	}
+
+	rows := make([][]interface{}, len(elems))
+	for i, elem := range elems {
+		row := make([]interface{}, len(fields))
+		for j, f := range fields {
+			if field, ok := fieldValue(elem, f.index); ok {
+				row[j] = field.Interface()
+			}
+		}
+		rows[i] = row
+	}
+
+	b = builder.Set(b, "Columns", cols).(InsertBuilder)
+	b = builder.Set(b, "Values", rows).(InsertBuilder)
+	return b
 }
@@ -46,23 +46,23 @@ func TestInsertBuilderPlaceholders(t *testing.T) {
 	sql, _, _ = b.PlaceholderFormat(Dollar).ToSql()
 	assert.Equal(t, "INSERT INTO test VALUES ($1,$2)", sql)
 }
-//
-//func TestInsertBuilderRunners(t *testing.T) {
-//	db := &DBStub{}
-//	b := Insert("test").Values(1).RunWith(db)
-//
-//	expectedSql := "INSERT INTO test VALUES (?)"
-//
-//	b.Exec()
-//	assert.Equal(t, expectedSql, db.LastExecSql)
-//}
-//
-//func TestInsertBuilderNoRunner(t *testing.T) {
-//	b := Insert("test").Values(1)
-//
-//	_, err := b.Exec()
-//	assert.Equal(t, RunnerNotSet, err)
-//}
+
+func TestInsertBuilderRunners(t *testing.T) {
+	db := &DBStub{}
+	b := Insert("test").Values(1).RunWith(db)
+
+	expectedSql := "INSERT INTO test VALUES (?)"
+
+	b.Exec()
+	assert.Equal(t, expectedSql, db.LastExecSql)
+}
+
+func TestInsertBuilderNoRunner(t *testing.T) {
+	b := Insert("test").Values(1)
+
+	_, err := b.Exec()
+	assert.Equal(t, RunnerNotSet, err)
+}
 
 func TestInsertBuilderSetMap(t *testing.T) {
 	b := Insert("table").SetMap(Eq{"field1": 1})
@@ -77,16 +77,301 @@ func TestInsertBuilderSetMap(t *testing.T) {
 	assert.Equal(t, expectedArgs, args)
 }
 
-//func TestInsertBuilderSelect(t *testing.T) {
-//	sb := Select("field1").From("table1").Where(Eq{"field1": 1})
-//	ib := Insert("table2").Columns("field1").Select(sb)
-//
-//	sql, args, err := ib.ToSql()
-//	assert.NoError(t, err)
-//
-//	expectedSql := "INSERT INTO table2 (field1) SELECT field1 FROM table1 WHERE field1 = ?"
-//	assert.Equal(t, expectedSql, sql)
-//
-//	expectedArgs := []interface{}{1}
-//	assert.Equal(t, expectedArgs, args)
-//}
+func TestInsertBuilderRowWidth(t *testing.T) {
+	_, _, err := Insert("a").Columns("b", "c").Values(1, 2).Values(3).ToSql()
+	assert.EqualError(t, err, "insert row 1 has 1 values but expected 2")
+
+	_, _, err = Insert("a").Values(1, 2).Values(3, 4, 5).ToSql()
+	assert.EqualError(t, err, "insert row 1 has 3 values but expected 2")
+
+	_, err = Insert("a").Columns("b").Values(1, 2).Batches(10)
+	assert.Error(t, err)
+}
+
+func TestInsertBuilderValuesMap(t *testing.T) {
+	sql, args, err := Insert("a").
+		Columns("b", "c", "d").
+		ValuesMap(map[string]interface{}{"d": 1, "b": 2}).
+		Values(3, 4, Expr("NOW()")).
+		ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "INSERT INTO a (b,c,d) VALUES (?,DEFAULT,?),(?,?,NOW())", sql)
+	assert.Equal(t, []interface{}{2, 1, 3, 4}, args)
+
+	_, _, err = Insert("a").Columns("b").ValuesMap(map[string]interface{}{"x": 1}).ToSql()
+	assert.EqualError(t, err, `insert row 0: ValuesMap column "x" is not in Columns`)
+
+	_, _, err = Insert("a").ValuesMap(map[string]interface{}{"x": 1}).ToSql()
+	assert.Error(t, err)
+}
+
+func TestInsertBuilderDefault(t *testing.T) {
+	b := Insert("a").Columns("b", "c").Values(1, Default)
+
+	sql, args, err := b.ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "INSERT INTO a (b,c) VALUES (?,DEFAULT)", sql)
+	assert.Equal(t, []interface{}{1}, args)
+
+	_, _, err = b.Dialect(SQLite).ToSql()
+	assert.EqualError(t, err, "insert row 0: sqlite does not support DEFAULT in VALUES")
+}
+
+func TestInsertBuilderDefaultValues(t *testing.T) {
+	b := Insert("audit").DefaultValues()
+
+	sql, _, err := b.ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "INSERT INTO audit DEFAULT VALUES", sql)
+
+	sql, _, err = b.Dialect(MySQL).ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "INSERT INTO audit () VALUES ()", sql)
+
+	sql, _, err = b.Returning("id").Dialect(PostgreSQL).ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "INSERT INTO audit DEFAULT VALUES RETURNING id", sql)
+
+	sql, _, err = b.Returning("id").Dialect(SQLServer).ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "INSERT INTO audit OUTPUT INSERTED.id DEFAULT VALUES", sql)
+
+	_, _, err = b.Columns("a").ToSql()
+	assert.Error(t, err)
+}
+
+func TestInsertBuilderReplace(t *testing.T) {
+	b := Replace("a").Columns("id", "b").Values(1, 2)
+
+	sql, args, err := b.ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "REPLACE INTO a (id,b) VALUES (?,?)", sql)
+	assert.Equal(t, []interface{}{1, 2}, args)
+
+	sql, _, err = b.Dialect(SQLite).ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "INSERT OR REPLACE INTO a (id,b) VALUES (?,?)", sql)
+
+	_, _, err = b.Dialect(PostgreSQL).ToSql()
+	assert.Error(t, err)
+
+	sql, _, err = b.OnConflict("id").Dialect(PostgreSQL).ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "INSERT INTO a (id,b) VALUES (?,?) ON CONFLICT (id) DO UPDATE SET b = EXCLUDED.b", sql)
+
+	_, _, err = b.Dialect(SQLServer).ToSql()
+	assert.Error(t, err)
+
+	_, _, err = b.Ignore().ToSql()
+	assert.Error(t, err)
+
+	_, _, err = b.OnDuplicateKeyUpdate(map[string]interface{}{"b": 3}).Dialect(MySQL).ToSql()
+	assert.Error(t, err)
+
+	_, _, err = b.OnConflict("id").DoUpdateSet("b", 3).Dialect(PostgreSQL).ToSql()
+	assert.Error(t, err)
+}
+
+func TestInsertBuilderIgnore(t *testing.T) {
+	b := Insert("a").Columns("id").Values(1).Ignore()
+
+	sql, _, err := b.Dialect(MySQL).ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "INSERT IGNORE INTO a (id) VALUES (?)", sql)
+
+	sql, _, err = b.Dialect(SQLite).ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "INSERT OR IGNORE INTO a (id) VALUES (?)", sql)
+
+	sql, _, err = b.Dialect(PostgreSQL).ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "INSERT INTO a (id) VALUES (?) ON CONFLICT DO NOTHING", sql)
+
+	sql, _, err = b.OnConflict("id").Returning("id").Dialect(PostgreSQL).ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "INSERT INTO a (id) VALUES (?) ON CONFLICT (id) DO NOTHING RETURNING id", sql)
+}
+
+func TestInsertBuilderSelect(t *testing.T) {
+	sb := Select("field1").From("table1").Where(Eq{"field1": 1})
+	ib := Insert("table2").Columns("field1").Select(sb)
+
+	sql, args, err := ib.ToSql()
+	assert.NoError(t, err)
+
+	expectedSql := "INSERT INTO table2 (field1) SELECT field1 FROM table1 WHERE field1 = ?"
+	assert.Equal(t, expectedSql, sql)
+
+	expectedArgs := []interface{}{1}
+	assert.Equal(t, expectedArgs, args)
+}
+
+func TestInsertBuilderSelectPlaceholders(t *testing.T) {
+	sb := Select("a", "b").
+		Prefix("WITH recent AS (SELECT * FROM events WHERE at > ?)", 10).
+		From("recent").
+		Where("kind = ?", "x").
+		Suffix("UNION ALL SELECT a, b FROM archive WHERE kind = ?", "y").
+		PlaceholderFormat(Dollar)
+	sql, args, err := Insert("t").Prefix("/* ? */", 0).Columns("a", "b").Select(sb).PlaceholderFormat(Dollar).ToSql()
+	assert.NoError(t, err)
+	expectedSql := "/* $1 */ INSERT INTO t (a,b) WITH recent AS (SELECT * FROM events WHERE at > $2) " +
+		"SELECT a, b FROM recent WHERE kind = $3 UNION ALL SELECT a, b FROM archive WHERE kind = $4"
+	assert.Equal(t, expectedSql, sql)
+	assert.Equal(t, []interface{}{0, 10, "x", "y"}, args)
+
+	sql, args, err = Insert("t").Columns("a").Select(Expr("VALUES (?), (?)", 1, 2)).PlaceholderFormat(Dollar).ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "INSERT INTO t (a) VALUES ($1), ($2)", sql)
+	assert.Equal(t, []interface{}{1, 2}, args)
+}
+
+func TestInsertBuilderSelectColumnCount(t *testing.T) {
+	_, _, err := Insert("t").Columns("a", "b").Select(Select("a, COALESCE(b, c, d)", "e").From("s")).ToSql()
+	assert.EqualError(t, err, "insert has 2 columns but its select returns 3")
+
+	_, _, err = Insert("t").Columns("a", "b").Select(Select("*").From("s")).ToSql()
+	assert.NoError(t, err)
+}
+
+func TestInsertBuilderOnConflict(t *testing.T) {
+	b := Insert("a").Columns("id", "b", "c").Values(1, 2, 3).
+		OnConflict("id").
+		DoUpdateSet("b", Excluded("b")).
+		DoUpdateSet("c", Expr("a.c + ?", 4)).
+		Suffix("RETURNING id")
+
+	sql, args, err := b.PlaceholderFormat(Dollar).ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "INSERT INTO a (id,b,c) VALUES ($1,$2,$3) ON CONFLICT (id) DO UPDATE SET b = EXCLUDED.b, c = a.c + $4 RETURNING id", sql)
+	assert.Equal(t, []interface{}{1, 2, 3, 4}, args)
+
+	sql, args, err = b.Dialect(MySQL).ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "INSERT INTO a (id,b,c) VALUES (?,?,?) ON DUPLICATE KEY UPDATE b = VALUES(b), c = a.c + ? RETURNING id", sql)
+	assert.Equal(t, []interface{}{1, 2, 3, 4}, args)
+
+	sql, _, err = Insert("a").Values(1).OnConflict().DoNothing().ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "INSERT INTO a VALUES (?) ON CONFLICT DO NOTHING", sql)
+}
+
+func TestInsertBuilderOnDuplicateKeyUpdate(t *testing.T) {
+	b := Insert("a").Columns("id", "b").Values(1, 2).
+		OnDuplicateKeyUpdate(map[string]interface{}{"b": Excluded("b"), "c": 3})
+
+	sql, args, err := b.ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "INSERT INTO a (id,b) VALUES (?,?) ON DUPLICATE KEY UPDATE b = VALUES(b), c = ?", sql)
+	assert.Equal(t, []interface{}{1, 2, 3}, args)
+
+	sql, _, err = b.OnConflict("id").Dialect(PostgreSQL).ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "INSERT INTO a (id,b) VALUES (?,?) ON CONFLICT (id) DO UPDATE SET b = EXCLUDED.b, c = ?", sql)
+}
+
+func TestInsertBuilderOnConflictErr(t *testing.T) {
+	_, _, err := Insert("a").Values(1).OnConflict("id").ToSql()
+	assert.Error(t, err)
+
+	_, _, err = Insert("a").Values(1).DoUpdateSet("b", 1).ToSql()
+	assert.Error(t, err)
+
+	_, _, err = Insert("a").Values(1).OnDuplicateKeyUpdate(Eq{"b": 1}).Dialect(PostgreSQL).ToSql()
+	assert.Error(t, err)
+
+	_, _, err = Insert("a").Values(1).OnConflict("id").DoNothing().Dialect(MySQL).ToSql()
+	assert.Error(t, err)
+}
+
+func TestInsertBuilderReturning(t *testing.T) {
+	b := Insert("a").Columns("b").Values(1).Returning("id", "created_at")
+
+	sql, args, err := b.Dialect(PostgreSQL).PlaceholderFormat(Dollar).ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "INSERT INTO a (b) VALUES ($1) RETURNING id, created_at", sql)
+	assert.Equal(t, []interface{}{1}, args)
+
+	sql, _, err = b.Dialect(SQLServer).ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "INSERT INTO a (b) OUTPUT INSERTED.id, INSERTED.created_at VALUES (?)", sql)
+
+	_, _, err = b.Dialect(MySQL).ToSql()
+	assert.Error(t, err)
+
+	db := &DBStub{}
+	var id int
+	err = b.RunWith(db).QueryRow().Scan(&id)
+	assert.NoError(t, err)
+	assert.Equal(t, "INSERT INTO a (b) VALUES (?) RETURNING id, created_at", db.LastQueryRowSql)
+}
+
+func TestInsertBuilderBatches(t *testing.T) {
+	b := Insert("a").
+		Prefix("/* ? */", 0).
+		Columns("b", "c").
+		Values(1, 2).
+		Values(3, Expr("? + ?", 4, 5)).
+		Values(6, 7).
+		OnConflict("b").DoUpdateSet("c", Expr("?", 8))
+
+	batches, err := b.Batches(5)
+	assert.NoError(t, err)
+	assert.Len(t, batches, 3)
+
+	sql, args, err := batches[1].ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "/* ? */ INSERT INTO a (b,c) VALUES (?,? + ?) ON CONFLICT (b) DO UPDATE SET c = ?", sql)
+	assert.Equal(t, []interface{}{0, 3, 4, 5, 8}, args)
+
+	batches, err = b.Batches(7)
+	assert.NoError(t, err)
+	assert.Len(t, batches, 2)
+
+	sql, args, err = batches[1].ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "/* ? */ INSERT INTO a (b,c) VALUES (?,?) ON CONFLICT (b) DO UPDATE SET c = ?", sql)
+	assert.Equal(t, []interface{}{0, 6, 7, 8}, args)
+
+	_, err = b.Batches(4)
+	assert.Error(t, err)
+
+	_, err = b.Batches(0)
+	assert.Error(t, err)
+
+	batches, err = b.Dialect(SQLite).Batches(0)
+	assert.NoError(t, err)
+	assert.Len(t, batches, 1)
+}
+
+func TestInsertBuilderExecBatches(t *testing.T) {
+	db := &DBStub{RowsAffected: 2}
+	n, err := Insert("a").Values(1, 2).Values(3, 4).Values(5, 6).RunWith(db).ExecBatches(4)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(4), n)
+	assert.Equal(t, 2, db.ExecCount)
+	assert.Equal(t, "INSERT INTO a VALUES (?,?)", db.LastExecSql)
+}
+
+func TestInsertBuilderSetStruct(t *testing.T) {
+	user := testUser{Name: "moe", Notes: "ignored"}
+
+	sql, args, err := Insert("users").SetStruct(&user).ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "INSERT INTO users (name) VALUES (?)", sql)
+	assert.Equal(t, []interface{}{"moe"}, args)
+}
+
+func TestInsertBuilderValuesStructs(t *testing.T) {
+	users := []testUser{{Name: "moe"}, {Name: "larry", Email: "larry@example.com"}}
+
+	sql, args, err := Insert("users").ValuesStructs(users).ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "INSERT INTO users (name,email) VALUES (?,?),(?,?)", sql)
+	assert.Equal(t, []interface{}{"moe", "", "larry", "larry@example.com"}, args)
+
+	assert.Panics(t, func() { Insert("users").ValuesStructs(users[0]) })
+	assert.PanicsWithValue(t, "ValuesStructs element 1 is a nil *squirrel.testUser", func() {
+		Insert("users").ValuesStructs([]*testUser{&users[0], nil})
+	})
+}
@@ -1,6 +1,7 @@
 package squirrel
 
 import (
+	"bytes"
 	"fmt"
 	"io"
 )
@@ -30,8 +31,8 @@ func (p part) ToSql() (sql string, args []interface{}, err error) {
 }
 
 func appendToSql(parts []Sqlizer, w io.Writer, sep string, args []interface{}) ([]interface{}, error) {
-	length := len(parts)
-	for i, p := range parts {
+	written := false
+	for _, p := range parts {
 		partSql, partArgs, err := p.ToSql()
 		if err != nil {
 			return nil, err
@@ -39,19 +40,33 @@ func appendToSql(parts []Sqlizer, w io.Writer, sep string, args []interface{}) (
 			continue
 		}
 
-		_, err = io.WriteString(w, partSql)
-		if err != nil {
-			return nil, err
-		}
-
-		if i < length-1 {
+		if written {
 			_, err := io.WriteString(w, sep)
 			if err != nil {
 				return nil, err
 			}
 		}
 
+		_, err = io.WriteString(w, partSql)
+		if err != nil {
+			return nil, err
+		}
+		written = true
+
 		args = append(args, partArgs...)
 	}
 	return args, nil
 }
+
+// appendWhereToSql writes " WHERE " and the parts ANDed together, or nothing
+// when every part renders empty. ok reports whether a WHERE clause was written.
+func appendWhereToSql(parts []Sqlizer, w io.Writer, args []interface{}) (_ []interface{}, ok bool, err error) {
+	where := &bytes.Buffer{}
+	args, err = appendToSql(parts, where, " AND ", args)
+	if err != nil || where.Len() == 0 {
+		return args, false, err
+	}
+	io.WriteString(w, " WHERE ")
+	_, err = where.WriteTo(w)
+	return args, true, err
+}
@@ -0,0 +1,412 @@
+package squirrel
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/lann/builder"
+)
+
+// statementPolicies are the policies set on a StatementBuilderType that apply
+// to the statements built from it. Every data struct embeds them so that the
+// builders can be derived from any StatementBuilderType.
+type statementPolicies struct {
+	RequireWhere  bool
+	SoftDelete    *softDelete
+	Audit         *Audit
+	Tenant        *tenantScope
+	TableResolver TableResolver
+}
+
+// ErrMissingWhere is returned by ToSql for an update or delete without a WHERE
+// clause when RequireWhere is set.
+var ErrMissingWhere = errors.New("statement has no WHERE clause, call AllRows to affect every row")
+
+// RequireWhere makes update and delete statements built from this
+// StatementBuilderType return ErrMissingWhere unless their WHERE clause is
+// non-empty or AllRows was called.
+func (b StatementBuilderType) RequireWhere(require bool) StatementBuilderType {
+	return builder.Set(b, "RequireWhere", require).(StatementBuilderType)
+}
+
+// softDelete marks rows of tables as deleted by setting column instead of
+// deleting them. An empty tables applies to every table.
+type softDelete struct {
+	column string
+	tables []string
+}
+
+// SoftDelete makes the tables built from this StatementBuilderType soft
+// deleted: Delete sets column to the current time instead of deleting rows,
+// and Select and Update skip rows where column is set, in their table and in
+// the tables they join. Without tables, every table is soft deleted.
+//
+// See SelectBuilder.WithDeleted, UpdateBuilder.WithDeleted and
+// DeleteBuilder.HardDelete.
+func (b StatementBuilderType) SoftDelete(column string, tables ...string) StatementBuilderType {
+	return builder.Set(b, "SoftDelete", &softDelete{column: column, tables: tables}).(StatementBuilderType)
+}
+
+// tableScopes returns the predicates the policies add for table, the table of
+// the statement. joined reports whether the statement has other tables.
+func (p statementPolicies) tableScopes(table string, joined, withDeleted bool) []Sqlizer {
+	var scopes []Sqlizer
+	if scope := p.softDeleteScope(table, joined); scope != nil && !withDeleted {
+		scopes = append(scopes, scope)
+	}
+	if scope := p.tenantScope(table, joined); scope != nil {
+		scopes = append(scopes, scope)
+	}
+	return scopes
+}
+
+// appliesTo reports whether a policy for tables applies to the table
+// expression table. An empty tables applies to every table.
+func appliesTo(tables []string, table string) bool {
+	if len(tables) == 0 {
+		return true
+	}
+	name := tableName(table)
+	for _, t := range tables {
+		if t == name {
+			return true
+		}
+	}
+	return false
+}
+
+// softDeleted reports whether the table expression table is soft deleted.
+func (p statementPolicies) softDeleted(table string) bool {
+	return p.SoftDelete != nil && appliesTo(p.SoftDelete.tables, table)
+}
+
+// softDeleteScope returns the predicate skipping soft deleted rows of table,
+// or nil if table is not soft deleted.
+func (p statementPolicies) softDeleteScope(table string, joined bool) Sqlizer {
+	if !p.softDeleted(table) {
+		return nil
+	}
+	return Expr(qualify(table, joined, p.SoftDelete.column) + " IS NULL")
+}
+
+// tableName returns the table of a table expression like "orders o".
+func tableName(table string) string {
+	fields := strings.Fields(table)
+	if len(fields) == 0 {
+		return table
+	}
+	return fields[0]
+}
+
+// qualify prefixes column with the alias of table when table has one or the
+// statement joins other tables.
+func qualify(table string, joined bool, column string) string {
+	if alias := tableAlias(table); alias != table || joined {
+		return alias + "." + column
+	}
+	return column
+}
+
+// currentTimestamp returns the SQL for the current time in the dialect.
+func (d Dialect) currentTimestamp() string {
+	switch d {
+	case SQLite, SQLServer:
+		return "CURRENT_TIMESTAMP"
+	}
+	return "NOW()"
+}
+
+// fromTable returns the table set by SelectBuilder.From, if From is not a
+// subquery.
+func fromTable(from Sqlizer) (string, bool) {
+	if p, ok := from.(*part); ok {
+		table, ok := p.pred.(string)
+		return table, ok && singleTable(table)
+	}
+	return "", false
+}
+
+// singleTable reports whether the table expression table names a single
+// table, not a list of tables or a subquery.
+func singleTable(table string) bool {
+	return !strings.ContainsAny(table, "(,")
+}
+
+// fromScopes returns the scopes of the table set by From. A From or
+// FromSelect the tenant policy cannot scope, like a subquery or a list of
+// tables, is an error rather than a statement reading across tenants. Other
+// Sqlizers, like the VALUES list of BulkSet, hold no rows of a tenant.
+func (p statementPolicies) fromScopes(from Sqlizer, joined, withDeleted bool) ([]Sqlizer, error) {
+	if from == nil {
+		return nil, nil
+	}
+	table, ok := fromTable(from)
+	if !ok {
+		switch from.(type) {
+		case *part, aliasExpr:
+			if p.Tenant != nil {
+				return nil, errors.New("cannot scope the tenant of a FROM subquery or list of tables, join the tables one by one")
+			}
+		}
+		return nil, nil
+	}
+	return p.tableScopes(table, joined, withDeleted), nil
+}
+
+// checkTenantTables returns an error if the tenant policy is set and any of
+// tables is not a single table it can scope.
+func (p statementPolicies) checkTenantTables(tables ...string) error {
+	if p.Tenant == nil {
+		return nil
+	}
+	for _, table := range tables {
+		if !singleTable(table) {
+			return fmt.Errorf("cannot scope the tenant of %q, name a single table", table)
+		}
+	}
+	return nil
+}
+
+// appendScopedWhereToSql is appendWhereToSql for parts followed by the scopes
+// added by statement policies. The predicate of parts is parenthesized so that
+// an OR in it cannot escape the scopes. ok only reports whether parts rendered
+// a predicate, as scopes alone do not bound a statement.
+func appendScopedWhereToSql(parts, scopes []Sqlizer, w io.Writer, args []interface{}) (_ []interface{}, ok bool, err error) {
+	where := &bytes.Buffer{}
+	args, err = appendToSql(parts, where, " AND ", args)
+	if err != nil {
+		return args, false, err
+	}
+	ok = where.Len() > 0
+
+	scoped := &bytes.Buffer{}
+	args, err = appendToSql(scopes, scoped, " AND ", args)
+	if err != nil {
+		return args, false, err
+	}
+	switch {
+	case scoped.Len() == 0 && ok:
+		io.WriteString(w, " WHERE ")
+		where.WriteTo(w)
+	case scoped.Len() > 0 && ok:
+		io.WriteString(w, " WHERE (")
+		where.WriteTo(w)
+		io.WriteString(w, ") AND ")
+		scoped.WriteTo(w)
+	case scoped.Len() > 0:
+		io.WriteString(w, " WHERE ")
+		scoped.WriteTo(w)
+	}
+	return args, ok, nil
+}
+
+// Audit names the audit columns that inserts and updates built from a
+// StatementBuilderType fill in, unless the caller already set them. Empty
+// column names are skipped. The DO UPDATE SET or ON DUPLICATE KEY UPDATE
+// clause of an upsert sets the update columns too. Inserts from a Select or
+// of Values without Columns are left as is, as their values cannot be matched
+// to columns.
+type Audit struct {
+	// CreatedAt is set to the current time by inserts.
+	CreatedAt string
+	// UpdatedAt is set to the current time by inserts and updates.
+	UpdatedAt string
+	// CreatedBy is set to the actor by inserts.
+	CreatedBy string
+	// UpdatedBy is set to the actor by inserts and updates.
+	UpdatedBy string
+
+	// Now returns the current time. It defaults to time.Now.
+	Now func() time.Time
+	// Actor returns the actor of the statement from the context passed to
+	// ExecContext and the other context methods, or context.Background for
+	// ToSql and Exec. It defaults to ActorFromContext. The actor columns are
+	// skipped when it returns nil.
+	Actor func(ctx context.Context) interface{}
+}
+
+// Audit sets the audit columns filled in by the inserts and updates built from
+// this StatementBuilderType.
+//
+// Ex:
+//
+//	sb := StatementBuilder.Audit(Audit{CreatedAt: "created_at", UpdatedAt: "updated_at"})
+func (b StatementBuilderType) Audit(a Audit) StatementBuilderType {
+	return builder.Set(b, "Audit", &a).(StatementBuilderType)
+}
+
+type actorKey struct{}
+
+// ContextWithActor returns a copy of ctx carrying actor, for the default
+// Audit.Actor.
+func ContextWithActor(ctx context.Context, actor interface{}) context.Context {
+	return context.WithValue(ctx, actorKey{}, actor)
+}
+
+// ActorFromContext returns the actor set by ContextWithActor, or nil.
+func ActorFromContext(ctx context.Context) interface{} {
+	return ctx.Value(actorKey{})
+}
+
+// columns returns the audit columns an insert, or else an update, needs in
+// addition to the ones for which set reports true, and their values.
+func (a *Audit) columns(ctx context.Context, insert bool, set func(string) bool) ([]string, []interface{}) {
+	now := time.Now
+	if a.Now != nil {
+		now = a.Now
+	}
+	actorOf := ActorFromContext
+	if a.Actor != nil {
+		actorOf = a.Actor
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	at, actor := now(), actorOf(ctx)
+
+	var (
+		columns []string
+		values  []interface{}
+	)
+	add := func(column string, value interface{}) {
+		if column != "" && value != nil && !set(column) {
+			columns = append(columns, column)
+			values = append(values, value)
+		}
+	}
+	if insert {
+		add(a.CreatedAt, at)
+	}
+	add(a.UpdatedAt, at)
+	if insert {
+		add(a.CreatedBy, actor)
+	}
+	add(a.UpdatedBy, actor)
+	return columns, values
+}
+
+// tenantScope restricts statements to the rows of a tenant.
+type tenantScope struct {
+	column string
+	id     interface{}
+	tables []string
+}
+
+// WithTenant scopes the statements built from this StatementBuilderType to the
+// tenant id stored in column of tables, or of every table without tables.
+// Selects, updates and deletes get "<alias>.column = ?" for their table and
+// for each scoped table they join, in the ON clause of the join. Inserts get
+// column set on every row unless the caller set it, which must be to id.
+// Statements the policy cannot scope are an error: a FROM subquery or list of
+// tables, a join of a subquery or of several tables, and an insert into a
+// scoped table from a Select or without Columns that does not set column
+// itself.
+func (b StatementBuilderType) WithTenant(column string, id interface{}, tables ...string) StatementBuilderType {
+	return builder.Set(b, "Tenant", &tenantScope{column: column, id: id, tables: tables}).(StatementBuilderType)
+}
+
+// owns reports whether the value v set by a caller is the id of the tenant.
+// Integers of any type compare by value, Sqlizers cannot be checked.
+func (t *tenantScope) owns(v interface{}) bool {
+	if _, ok := v.(Sqlizer); ok {
+		return false
+	}
+	a, b := reflect.ValueOf(v), reflect.ValueOf(t.id)
+	switch {
+	case a.CanInt() && b.CanInt():
+		return a.Int() == b.Int()
+	case a.CanUint() && b.CanUint():
+		return a.Uint() == b.Uint()
+	case a.CanInt() && b.CanUint():
+		return a.Int() >= 0 && uint64(a.Int()) == b.Uint()
+	case a.CanUint() && b.CanInt():
+		return b.Int() >= 0 && a.Uint() == uint64(b.Int())
+	}
+	return reflect.DeepEqual(v, t.id)
+}
+
+// tenantScope returns the predicate restricting table to the tenant, or nil.
+func (p statementPolicies) tenantScope(table string, joined bool) Sqlizer {
+	if p.Tenant == nil || !appliesTo(p.Tenant.tables, table) {
+		return nil
+	}
+	return Expr(qualify(table, joined, p.Tenant.column)+" = ?", p.Tenant.id)
+}
+
+var (
+	joinTableRegexp   = regexp.MustCompile(`(?i)^\s*((?:NATURAL\s+)?(?:(LEFT|RIGHT|FULL)(?:\s+OUTER)?\s+|INNER\s+|CROSS\s+)?JOIN)\s+([^\s(]+)(?:\s+(?:AS\s+)?(\w+))?`)
+	joinKeywordRegexp = regexp.MustCompile(`(?i)\bJOIN\b`)
+	joinOnRegexp      = regexp.MustCompile(`(?i)\sON\s`)
+)
+
+// isJoinKeyword reports whether the word matched as the alias of a joined
+// table by joinTableRegexp starts its join condition instead.
+func isJoinKeyword(word string) bool {
+	return strings.EqualFold(word, "ON") || strings.EqualFold(word, "USING")
+}
+
+// scopeJoins adds the predicates the policies add for every table joined by
+// joins to the ON clause of its join, skipping soft deleted rows unless
+// withDeleted is set. Inner joins without an ON clause get them in the
+// returned WHERE scopes instead.
+func (p statementPolicies) scopeJoins(joins []Sqlizer, withDeleted bool) ([]Sqlizer, []Sqlizer, error) {
+	if p.Tenant == nil && (p.SoftDelete == nil || withDeleted) {
+		return joins, nil, nil
+	}
+	scoped := make([]Sqlizer, len(joins))
+	var scopes []Sqlizer
+	for i, join := range joins {
+		scoped[i] = join
+		sql, args, err := join.ToSql()
+		if err != nil {
+			return nil, nil, err
+		}
+		m := joinTableRegexp.FindStringSubmatch(sql)
+		if m == nil {
+			if p.Tenant != nil {
+				return nil, nil, fmt.Errorf("cannot scope the tenant of the source joined in %q, join a table", sql)
+			}
+			continue
+		}
+		table := m[3]
+		if m[4] != "" && !isJoinKeyword(m[4]) {
+			table += " " + m[4]
+		}
+		if len(joinKeywordRegexp.FindAllString(sql, -1)) > 1 || len(splitTopLevel(sql)) > 1 {
+			return nil, nil, fmt.Errorf("cannot scope several tables joined in %q, join them one by one", sql)
+		}
+		tableScopes := p.tableScopes(table, true, withDeleted)
+		if len(tableScopes) == 0 {
+			continue
+		}
+
+		on := joinOnRegexp.FindStringIndex(sql)
+		switch {
+		case on != nil:
+			var preds []string
+			args = args[:len(args):len(args)]
+			for _, scope := range tableScopes {
+				scopeSql, scopeArgs, err := scope.ToSql()
+				if err != nil {
+					return nil, nil, err
+				}
+				preds = append(preds, scopeSql)
+				args = append(args, scopeArgs...)
+			}
+			sql = fmt.Sprintf("%s(%s) AND %s", sql[:on[1]], sql[on[1]:], strings.Join(preds, " AND "))
+			scoped[i] = Expr(sql, args...)
+		case m[2] != "":
+			return nil, nil, fmt.Errorf("cannot scope outer join %q without an ON clause", sql)
+		default:
+			scopes = append(scopes, tableScopes...)
+		}
+	}
+	return scoped, scopes, nil
+}
@@ -0,0 +1,265 @@
+package squirrel
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSoftDelete(t *testing.T) {
+	sb := StatementBuilder.SoftDelete("deleted_at", "users")
+
+	sql, args, err := sb.Delete("users").Where("id = ? OR email = ?", 1, "x").ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "UPDATE users SET deleted_at = NOW() WHERE (id = ? OR email = ?) AND deleted_at IS NULL", sql)
+	assert.Equal(t, []interface{}{1, "x"}, args)
+
+	sql, _, err = sb.Dialect(SQLite).Delete("users").Where("id = ?", 1).ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "UPDATE users SET deleted_at = CURRENT_TIMESTAMP WHERE (id = ?) AND deleted_at IS NULL", sql)
+
+	sql, _, err = sb.Delete("users").Where("id = ?", 1).HardDelete().ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "DELETE FROM users WHERE id = ?", sql)
+
+	sql, _, err = sb.Delete("posts").Where("id = ?", 1).ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "DELETE FROM posts WHERE id = ?", sql)
+
+	_, _, err = sb.Delete("users u").Join("posts p ON p.user_id = u.id").ToSql()
+	assert.Error(t, err)
+
+	_, _, err = sb.RequireWhere(true).Delete("users").ToSql()
+	assert.Equal(t, ErrMissingWhere, err)
+}
+
+func TestSoftDeleteScopes(t *testing.T) {
+	sb := StatementBuilder.SoftDelete("deleted_at")
+
+	sql, _, err := sb.Select("*").From("users").ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT * FROM users WHERE deleted_at IS NULL", sql)
+
+	sql, _, err = sb.Select("u.name").From("users u").Join("posts p ON p.user_id = u.id").Where("p.id = ?", 1).ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT u.name FROM users u JOIN posts p ON (p.user_id = u.id) AND p.deleted_at IS NULL "+
+		"WHERE (p.id = ?) AND u.deleted_at IS NULL", sql)
+
+	sql, _, err = StatementBuilder.SoftDelete("deleted_at", "users").
+		Select("p.title").From("posts p").LeftJoin("users u ON u.id = p.user_id").ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT p.title FROM posts p LEFT JOIN users u ON (u.id = p.user_id) AND u.deleted_at IS NULL", sql)
+
+	sql, _, err = sb.Select("u.name").From("users u").Join("posts p ON p.user_id = u.id").WithDeleted().ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT u.name FROM users u JOIN posts p ON p.user_id = u.id", sql)
+
+	_, _, err = sb.Select("*").From("users u").LeftJoin("posts p USING (user_id)").ToSql()
+	assert.Error(t, err)
+
+	sql, _, err = sb.Select("*").From("users").WithDeleted().ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT * FROM users", sql)
+
+	sql, _, err = sb.Update("users").Set("name", "moe").Where("id = ?", 1).ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "UPDATE users SET name = ? WHERE (id = ?) AND deleted_at IS NULL", sql)
+
+	sql, _, err = sb.Update("users").Set("name", "moe").WithDeleted().ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "UPDATE users SET name = ?", sql)
+}
+
+func TestAudit(t *testing.T) {
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	sb := StatementBuilder.Audit(Audit{
+		CreatedAt: "created_at",
+		UpdatedAt: "updated_at",
+		CreatedBy: "created_by",
+		Now:       func() time.Time { return now },
+	})
+
+	sql, args, err := sb.Insert("users").Columns("name").Values("moe").Values("larry").ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "INSERT INTO users (name,created_at,updated_at) VALUES (?,?,?),(?,?,?)", sql)
+	assert.Equal(t, []interface{}{"moe", now, now, "larry", now, now}, args)
+
+	sql, args, err = sb.Insert("users").SetMap(map[string]interface{}{"created_at": 1}).ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "INSERT INTO users (created_at,updated_at) VALUES (?,?)", sql)
+	assert.Equal(t, []interface{}{1, now}, args)
+
+	sql, args, err = sb.Update("users").SetMap(map[string]interface{}{"name": "moe"}).Where("id = ?", 1).ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "UPDATE users SET name = ?, updated_at = ? WHERE id = ?", sql)
+	assert.Equal(t, []interface{}{"moe", now, 1}, args)
+
+	sql, _, err = sb.Update("users").Set("updated_at", Expr("NOW()")).ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "UPDATE users SET updated_at = NOW()", sql)
+
+	sql, args, err = sb.Insert("users").Columns("id", "name").Values(1, "moe").
+		OnConflict("id").DoUpdateSet("name", Excluded("name")).
+		Dialect(PostgreSQL).
+		ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "INSERT INTO users (id,name,created_at,updated_at) VALUES (?,?,?,?) "+
+		"ON CONFLICT (id) DO UPDATE SET name = EXCLUDED.name, updated_at = ?", sql)
+	assert.Equal(t, []interface{}{1, "moe", now, now, now}, args)
+
+	sql, _, err = sb.Insert("users").Columns("id", "name").Values(1, "moe").
+		OnDuplicateKeyUpdate(map[string]interface{}{"name": Excluded("name")}).
+		Dialect(MySQL).
+		ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "INSERT INTO users (id,name,created_at,updated_at) VALUES (?,?,?,?) "+
+		"ON DUPLICATE KEY UPDATE name = VALUES(name), updated_at = ?", sql)
+
+	sql, args, err = sb.Insert("users").DefaultValues().ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "INSERT INTO users (created_at,updated_at) VALUES (?,?)", sql)
+	assert.Equal(t, []interface{}{now, now}, args)
+}
+
+func TestAuditActor(t *testing.T) {
+	db := &DBStub{}
+	sb := StatementBuilder.Audit(Audit{CreatedBy: "created_by", UpdatedBy: "updated_by"}).RunWith(db)
+	ctx := ContextWithActor(context.Background(), "moe")
+
+	_, err := sb.Insert("users").Columns("name").Values("larry").ExecContext(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, "INSERT INTO users (name,created_by,updated_by) VALUES (?,?,?)", db.LastExecSql)
+	assert.Equal(t, []interface{}{"larry", "moe", "moe"}, db.LastExecArgs)
+
+	_, err = sb.Update("users").Set("name", "larry").Where("id = ?", 1).ExecContext(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, "UPDATE users SET name = ?, updated_by = ? WHERE id = ?", db.LastExecSql)
+
+	_, err = sb.SoftDelete("deleted_at").Delete("users").Where("id = ?", 1).ExecContext(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, "UPDATE users SET deleted_at = NOW(), updated_by = ? WHERE (id = ?) AND deleted_at IS NULL", db.LastExecSql)
+
+	db.ExecCount = 0
+	_, err = sb.Insert("users").Columns("name").Values("larry").Values("curly").ExecBatchesContext(ctx, 4)
+	assert.NoError(t, err)
+	assert.Equal(t, "INSERT INTO users (name,created_by,updated_by) VALUES (?,?,?)", db.LastExecSql)
+	assert.Equal(t, 2, db.ExecCount)
+
+	sql, _, err := sb.Insert("users").Columns("name").Values("larry").ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "INSERT INTO users (name) VALUES (?)", sql)
+}
+
+func TestWithTenant(t *testing.T) {
+	sb := StatementBuilder.WithTenant("tenant_id", 7, "users", "posts")
+
+	sql, args, err := sb.Select("*").From("users").Where("id = ?", 1).ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT * FROM users WHERE (id = ?) AND tenant_id = ?", sql)
+	assert.Equal(t, []interface{}{1, 7}, args)
+
+	sql, args, err = sb.Select("u.name", "p.title").
+		From("users u").
+		LeftJoin("posts AS p ON p.user_id = u.id AND p.draft = ?", false).
+		Join("tags t ON t.post_id = p.id").
+		ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT u.name, p.title FROM users u "+
+		"LEFT JOIN posts AS p ON (p.user_id = u.id AND p.draft = ?) AND p.tenant_id = ? "+
+		"JOIN tags t ON t.post_id = p.id "+
+		"WHERE u.tenant_id = ?", sql)
+	assert.Equal(t, []interface{}{false, 7, 7}, args)
+
+	_, _, err = sb.Select("*").From("users").LeftJoin("posts USING (user_id)").ToSql()
+	assert.Error(t, err)
+
+	sql, args, err = sb.Update("users").Set("name", "moe").Where("id = ?", 1).ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "UPDATE users SET name = ? WHERE (id = ?) AND tenant_id = ?", sql)
+	assert.Equal(t, []interface{}{"moe", 1, 7}, args)
+
+	sql, args, err = sb.Delete("posts").Using("users u").Where("posts.user_id = u.id").Dialect(PostgreSQL).ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "DELETE FROM posts USING users u WHERE (posts.user_id = u.id) AND posts.tenant_id = ? AND u.tenant_id = ?", sql)
+	assert.Equal(t, []interface{}{7, 7}, args)
+
+	sql, args, err = sb.Insert("users").Columns("name").Values("moe").ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "INSERT INTO users (name,tenant_id) VALUES (?,?)", sql)
+	assert.Equal(t, []interface{}{"moe", 7}, args)
+
+	sql, _, err = sb.Insert("tags").Columns("name").Values("go").ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "INSERT INTO tags (name) VALUES (?)", sql)
+
+	_, _, err = sb.Select("*").From("users u, posts p").ToSql()
+	assert.Error(t, err)
+
+	_, _, err = sb.Select("*").FromSelect(Select("id").From("tags"), "t").ToSql()
+	assert.Error(t, err)
+
+	_, _, err = sb.Update("users").Set("name", "moe").From("posts p, tags t").ToSql()
+	assert.Error(t, err)
+
+	_, _, err = sb.Delete("posts").Using("users u, tags t").Dialect(PostgreSQL).ToSql()
+	assert.Error(t, err)
+
+	_, _, err = sb.Select("*").From("tags t").Join("users u ON u.id = t.user_id, posts").ToSql()
+	assert.Error(t, err)
+
+	_, _, err = sb.Select("*").From("tags t").Join("users u ON u.id = t.user_id JOIN posts p ON p.user_id = u.id").ToSql()
+	assert.Error(t, err)
+
+	_, _, err = sb.Select("*").From("tags t").Join("(SELECT * FROM posts) p ON p.id = t.post_id").ToSql()
+	assert.Error(t, err)
+
+	_, _, err = sb.Select("*").From("tags t").JoinClause("CROSS APPLY posts p").ToSql()
+	assert.Error(t, err)
+
+	_, _, err = sb.Insert("users").Columns("name").Select(Select("name").From("staff")).ToSql()
+	assert.Error(t, err)
+
+	_, _, err = sb.Insert("users").Values("moe").ToSql()
+	assert.Error(t, err)
+
+	sql, args, err = sb.Insert("users").Columns("name", "tenant_id").Select(sb.Select("author", "tenant_id").From("posts")).ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "INSERT INTO users (name,tenant_id) SELECT author, tenant_id FROM posts WHERE tenant_id = ?", sql)
+	assert.Equal(t, []interface{}{7}, args)
+
+	sql, args, err = sb.Insert("users").SetMap(map[string]interface{}{"tenant_id": int64(7), "name": "moe"}).ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "INSERT INTO users (tenant_id,name) VALUES (?,?)", sql)
+	assert.Equal(t, []interface{}{int64(7), "moe"}, args)
+
+	_, _, err = sb.Insert("users").SetMap(map[string]interface{}{"tenant_id": 8, "name": "moe"}).ToSql()
+	assert.Error(t, err)
+
+	_, _, err = sb.Insert("users").Columns("name", "tenant_id").Values("moe", 7).Values("larry", 8).ToSql()
+	assert.Error(t, err)
+
+	_, _, err = sb.Insert("users").Columns("name", "tenant_id").Values("moe", Expr("?", 8)).ToSql()
+	assert.Error(t, err)
+
+	sql, args, err = sb.Insert("users").DefaultValues().ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "INSERT INTO users (tenant_id) VALUES (?)", sql)
+	assert.Equal(t, []interface{}{7}, args)
+}
+
+func TestWithTenantCountQuery(t *testing.T) {
+	sb := StatementBuilder.WithTenant("tenant_id", 7, "orders").SoftDelete("deleted_at")
+
+	sql, args, err := sb.Select("a", "count(*)").From("orders").GroupBy("a").CountQuery().ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT COUNT(*) FROM (SELECT a, count(*) FROM orders "+
+		"WHERE deleted_at IS NULL AND tenant_id = ? GROUP BY a) AS t", sql)
+	assert.Equal(t, []interface{}{7}, args)
+
+	sql, _, err = sb.Select("a").Distinct().From("orders").CountQuery().ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT COUNT(*) FROM (SELECT DISTINCT a FROM orders "+
+		"WHERE deleted_at IS NULL AND tenant_id = ?) AS t", sql)
+}
@@ -0,0 +1,192 @@
+package squirrel
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// rowsScanner is the part of *sql.Rows used to scan rows into structs.
+type rowsScanner interface {
+	Columns() ([]string, error)
+	Next() bool
+	Scan(dest ...interface{}) error
+	Err() error
+	Close() error
+}
+
+// scanTargets returns pointers to the fields of the struct v matching columns,
+// allocating nil embedded structs on the way. Columns of joined structs are
+// scanned into nullable temporaries instead, as an outer join without a match
+// leaves them NULL: the returned set copies the non-NULL ones to their fields
+// once the row is scanned, allocating nil joined structs only then so that
+// joined structs without a match stay nil.
+func scanTargets(v reflect.Value, columns []string) (targets []interface{}, set func() error, err error) {
+	byColumn := make(map[string]structField)
+	for _, f := range structFields(v.Type()) {
+		byColumn[strings.ToLower(f.column)] = f
+	}
+
+	type joinedTarget struct {
+		index []int
+		temp  reflect.Value
+	}
+	var joined []joinedTarget
+	targets = make([]interface{}, len(columns))
+	for i, column := range columns {
+		f, ok := byColumn[strings.ToLower(column)]
+		if !ok {
+			return nil, nil, fmt.Errorf("no db tagged field of %s for column %q", v.Type(), column)
+		}
+		if f.joined {
+			temp := reflect.New(reflect.PtrTo(v.Type().FieldByIndex(f.index).Type))
+			joined = append(joined, joinedTarget{index: f.index, temp: temp})
+			targets[i] = temp.Interface()
+			continue
+		}
+		field, err := allocField(v, f.index)
+		if err != nil {
+			return nil, nil, err
+		}
+		targets[i] = field.Addr().Interface()
+	}
+
+	set = func() error {
+		for _, j := range joined {
+			if j.temp.Elem().IsNil() {
+				continue
+			}
+			field, err := allocField(v, j.index)
+			if err != nil {
+				return err
+			}
+			field.Set(j.temp.Elem().Elem())
+		}
+		return nil
+	}
+	return targets, set, nil
+}
+
+// allocField returns the field of the struct v at index, allocating the nil
+// struct pointers on the way.
+func allocField(v reflect.Value, index []int) (reflect.Value, error) {
+	field := v
+	for j, x := range index {
+		if j > 0 && field.Kind() == reflect.Ptr {
+			if field.IsNil() {
+				if !field.CanSet() {
+					return reflect.Value{}, fmt.Errorf("cannot allocate unexported %s of %s", field.Type(), v.Type())
+				}
+				field.Set(reflect.New(field.Type().Elem()))
+			}
+			field = field.Elem()
+		}
+		field = field.Field(x)
+	}
+	return field, nil
+}
+
+// scanRow scans the current row into dest, a pointer to a struct or to any
+// other value database/sql can scan a single column into.
+func scanRow(rows rowsScanner, columns []string, dest reflect.Value) error {
+	if !isRowStruct(dest.Elem().Type()) {
+		if len(columns) != 1 {
+			return fmt.Errorf("cannot scan %d columns into %s", len(columns), dest.Elem().Type())
+		}
+		return rows.Scan(dest.Interface())
+	}
+
+	targets, set, err := scanTargets(dest.Elem(), columns)
+	if err != nil {
+		return err
+	}
+	if err = rows.Scan(targets...); err != nil {
+		return err
+	}
+	return set()
+}
+
+// scanOne scans the first row of rows into dest and closes rows. It returns
+// sql.ErrNoRows if there are no rows.
+func scanOne(rows rowsScanner, dest interface{}) error {
+	defer rows.Close()
+
+	v := reflect.ValueOf(dest)
+	if v.Kind() != reflect.Ptr || v.IsNil() {
+		return fmt.Errorf("expected a non-nil pointer, not %T", dest)
+	}
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return err
+	}
+	if !rows.Next() {
+		if err = rows.Err(); err != nil {
+			return err
+		}
+		return sql.ErrNoRows
+	}
+	if err = scanRow(rows, columns, v); err != nil {
+		return err
+	}
+	return rows.Close()
+}
+
+// scanAll appends all rows of rows to dest, a pointer to a slice of structs,
+// of pointers to structs or of single column values, and closes rows.
+func scanAll(rows rowsScanner, dest interface{}) error {
+	defer rows.Close()
+
+	v := reflect.ValueOf(dest)
+	if v.Kind() != reflect.Ptr || v.IsNil() || v.Elem().Kind() != reflect.Slice {
+		return fmt.Errorf("expected a pointer to a slice, not %T", dest)
+	}
+	slice := v.Elem()
+	elemType := slice.Type().Elem()
+	isPtr := elemType.Kind() == reflect.Ptr && isRowStruct(elemType.Elem())
+	if isPtr {
+		elemType = elemType.Elem()
+	}
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return err
+	}
+	for rows.Next() {
+		elem := reflect.New(elemType)
+		if err = scanRow(rows, columns, elem); err != nil {
+			return err
+		}
+		if isPtr {
+			slice = reflect.Append(slice, elem)
+		} else {
+			slice = reflect.Append(slice, elem.Elem())
+		}
+	}
+	if err = rows.Err(); err != nil {
+		return err
+	}
+	v.Elem().Set(slice)
+	return rows.Close()
+}
+
+// QueryAll runs the query built by s with db and returns its rows as a slice
+// of T. Columns are mapped to the db tagged fields of T if T is a struct, see
+// SelectBuilder.ScanAll.
+//
+// Ex:
+//
+//	users, err := QueryAll[User](ctx, db, Select("id", "name").From("users"))
+func QueryAll[T any](ctx context.Context, db QueryerContext, s Sqlizer) ([]T, error) {
+	rows, err := QueryContextWith(ctx, db, s)
+	if err != nil {
+		return nil, err
+	}
+	var all []T
+	if err = scanAll(rows, &all); err != nil {
+		return nil, err
+	}
+	return all, nil
+}
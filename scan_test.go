@@ -0,0 +1,179 @@
+package squirrel
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeRows is a rowsScanner over in-memory values.
+type fakeRows struct {
+	columns []string
+	values  [][]interface{}
+	current int
+	closed  bool
+}
+
+func (r *fakeRows) Columns() ([]string, error) { return r.columns, nil }
+
+func (r *fakeRows) Next() bool {
+	r.current++
+	return r.current <= len(r.values)
+}
+
+func (r *fakeRows) Scan(dest ...interface{}) error {
+	for i, d := range dest {
+		if err := fakeAssign(d, r.values[r.current-1][i]); err != nil {
+			return fmt.Errorf("column %s: %v", r.columns[i], err)
+		}
+	}
+	return nil
+}
+
+// fakeAssign stores v in d like database/sql does: NULL sets pointers to nil
+// and cannot be stored in other values but a sql.Scanner.
+func fakeAssign(d interface{}, v interface{}) error {
+	if s, ok := d.(sql.Scanner); ok {
+		return s.Scan(v)
+	}
+	dv := reflect.ValueOf(d).Elem()
+	switch {
+	case dv.Kind() == reflect.Ptr:
+		if v == nil {
+			dv.Set(reflect.Zero(dv.Type()))
+			return nil
+		}
+		dv.Set(reflect.New(dv.Type().Elem()))
+		return fakeAssign(dv.Interface(), v)
+	case v == nil:
+		return fmt.Errorf("converting NULL to %s is unsupported", dv.Type())
+	default:
+		dv.Set(reflect.ValueOf(v).Convert(dv.Type()))
+	}
+	return nil
+}
+
+func (r *fakeRows) Err() error { return nil }
+
+func (r *fakeRows) Close() error {
+	r.closed = true
+	return nil
+}
+
+type Editor struct {
+	UpdatedBy string `db:"updated_by"`
+}
+
+type testNullable struct {
+	ID    int64          `db:"id"`
+	Email *string        `db:"email"`
+	Nick  sql.NullString `db:"nick"`
+	*Editor
+}
+
+func TestScanOne(t *testing.T) {
+	rows := &fakeRows{
+		columns: []string{"id", "name", "email"},
+		values:  [][]interface{}{{int64(1), "moe", "moe@example.com"}},
+	}
+	var u testUser
+	err := scanOne(rows, &u)
+	assert.NoError(t, err)
+	assert.Equal(t, testUser{ID: 1, Name: "moe", Email: "moe@example.com"}, u)
+	assert.True(t, rows.closed)
+}
+
+func TestScanOneNoRows(t *testing.T) {
+	var u testUser
+	err := scanOne(&fakeRows{columns: []string{"id"}}, &u)
+	assert.Equal(t, sql.ErrNoRows, err)
+}
+
+func TestScanOneUnknownColumn(t *testing.T) {
+	rows := &fakeRows{columns: []string{"nope"}, values: [][]interface{}{{1}}}
+	var u testUser
+	err := scanOne(rows, &u)
+	assert.Error(t, err)
+}
+
+func TestScanAllNulls(t *testing.T) {
+	rows := &fakeRows{
+		columns: []string{"id", "email", "nick", "updated_by"},
+		values: [][]interface{}{
+			{int64(1), nil, nil, "curly"},
+			{int64(2), "larry@example.com", "lar", "moe"},
+		},
+	}
+	var all []*testNullable
+	err := scanAll(rows, &all)
+	assert.NoError(t, err)
+	if assert.Len(t, all, 2) {
+		assert.Nil(t, all[0].Email)
+		assert.False(t, all[0].Nick.Valid)
+		assert.Equal(t, "curly", all[0].UpdatedBy)
+		assert.Equal(t, "larry@example.com", *all[1].Email)
+		assert.Equal(t, sql.NullString{String: "lar", Valid: true}, all[1].Nick)
+	}
+}
+
+func TestScanAllSingleColumn(t *testing.T) {
+	rows := &fakeRows{columns: []string{"id"}, values: [][]interface{}{{int64(1)}, {int64(2)}}}
+	var ids []int64
+	err := scanAll(rows, &ids)
+	assert.NoError(t, err)
+	assert.Equal(t, []int64{1, 2}, ids)
+
+	rows = &fakeRows{columns: []string{"id", "name"}, values: [][]interface{}{{int64(1), "moe"}}}
+	err = scanAll(rows, &ids)
+	assert.Error(t, err)
+}
+
+func TestScanAllNotSlice(t *testing.T) {
+	var u testUser
+	err := scanAll(&fakeRows{}, &u)
+	assert.Error(t, err)
+}
+
+func TestScanStructRunnerNotSet(t *testing.T) {
+	var u testUser
+	err := Select("id").From("users").ScanStruct(context.Background(), &u)
+	assert.Equal(t, RunnerNotSet, err)
+}
+
+func TestScanAllJoined(t *testing.T) {
+	rows := &fakeRows{
+		columns: []string{"id", "title", "author.id", "author.name"},
+		values:  [][]interface{}{{int64(1), "hi", int64(2), "moe"}},
+	}
+	var posts []testPost
+	err := scanAll(rows, &posts)
+	assert.NoError(t, err)
+	if assert.Len(t, posts, 1) {
+		assert.Equal(t, &testAuthor{ID: 2, Name: "moe"}, posts[0].Author)
+	}
+}
+
+func TestScanAllLeftJoinWithoutMatch(t *testing.T) {
+	rows := &fakeRows{
+		columns: []string{"id", "title", "author.id", "author.name"},
+		values: [][]interface{}{
+			{int64(1), "hi", nil, nil},
+			{int64(2), "yo", int64(3), "larry"},
+		},
+	}
+	var posts []testPost
+	err := scanAll(rows, &posts)
+	assert.NoError(t, err)
+	if assert.Len(t, posts, 2) {
+		assert.Nil(t, posts[0].Author)
+		assert.Equal(t, &testAuthor{ID: 3, Name: "larry"}, posts[1].Author)
+	}
+
+	rows = &fakeRows{columns: []string{"id", "title"}, values: [][]interface{}{{nil, "hi"}}}
+	err = scanAll(rows, &posts)
+	assert.Error(t, err)
+}
@@ -0,0 +1,162 @@
+package squirrel
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// Cursor holds the ORDER BY values of the last row of a page, in ORDER BY
+// order. It is passed to SelectBuilder.SeekAfter to fetch the next page.
+type Cursor []interface{}
+
+// Encode returns c as an opaque string that can be handed to clients and
+// turned back into a Cursor with DecodeCursor.
+//
+// Values are encoded as JSON, so e.g. a time.Time comes back as a string.
+func (c Cursor) Encode() (string, error) {
+	data, err := json.Marshal([]interface{}(c))
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+// DecodeCursor parses a string returned by Cursor.Encode. Whole numbers are
+// decoded as int64 and other numbers as float64.
+func DecodeCursor(s string) (Cursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cursor: %v", err)
+	}
+
+	var values []interface{}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	if err = dec.Decode(&values); err != nil {
+		return nil, fmt.Errorf("invalid cursor: %v", err)
+	}
+
+	for i, v := range values {
+		if n, ok := v.(json.Number); ok {
+			if values[i], err = n.Int64(); err != nil {
+				if values[i], err = n.Float64(); err != nil {
+					return nil, fmt.Errorf("invalid cursor: %v", err)
+				}
+			}
+		}
+	}
+	return Cursor(values), nil
+}
+
+// seekPredicate builds the condition selecting the rows that come after
+// cursor in the order given by orderBys.
+func seekPredicate(orderBys []string, cursor Cursor, rowValues bool) (Sqlizer, error) {
+	var (
+		columns []string
+		ops     []string
+	)
+	for _, orderBy := range orderBys {
+		for _, item := range splitTopLevel(orderBy) {
+			column, op, err := seekColumn(item)
+			if err != nil {
+				return nil, err
+			}
+			columns = append(columns, column)
+			ops = append(ops, op)
+		}
+	}
+
+	if len(columns) == 0 {
+		return nil, fmt.Errorf("seek pagination requires an ORDER BY clause")
+	}
+	if len(columns) != len(cursor) {
+		return nil, fmt.Errorf(
+			"seek cursor has %d values but the query is ordered by %d columns",
+			len(cursor), len(columns))
+	}
+
+	if len(columns) == 1 {
+		return Expr(fmt.Sprintf("%s %s ?", columns[0], ops[0]), cursor[0]), nil
+	}
+
+	sameOp := true
+	for _, op := range ops[1:] {
+		sameOp = sameOp && op == ops[0]
+	}
+	if sameOp && rowValues {
+		sql := fmt.Sprintf("(%s) %s (%s)",
+			strings.Join(columns, ", "), ops[0], Placeholders(len(columns)))
+		return Expr(sql, cursor...), nil
+	}
+
+	// (a > ? OR (a = ? AND b < ?) OR ...)
+	or := Or{}
+	for i := range columns {
+		and := And{}
+		for j := 0; j < i; j++ {
+			and = append(and, Expr(columns[j]+" = ?", cursor[j]))
+		}
+		and = append(and, Expr(fmt.Sprintf("%s %s ?", columns[i], ops[i]), cursor[i]))
+		if len(and) == 1 {
+			or = append(or, and[0])
+		} else {
+			or = append(or, and)
+		}
+	}
+	return or, nil
+}
+
+// seekColumn splits an ORDER BY item like "created_at DESC" into its column
+// and the comparison operator selecting the rows that follow.
+func seekColumn(item string) (column, op string, err error) {
+	column = strings.TrimSpace(item)
+	op = ">"
+
+	upper := strings.ToUpper(column)
+	switch {
+	case strings.HasSuffix(upper, " DESC"):
+		column, op = column[:len(column)-len(" DESC")], "<"
+	case strings.HasSuffix(upper, " ASC"):
+		column = column[:len(column)-len(" ASC")]
+	case strings.Contains(upper, " NULLS "):
+		return "", "", fmt.Errorf("cannot seek on ORDER BY %q: NULLS FIRST/LAST is not supported", item)
+	}
+
+	column = strings.TrimSpace(column)
+	if column == "" {
+		return "", "", fmt.Errorf("cannot seek on ORDER BY %q", item)
+	}
+	return column, op, nil
+}
+
+// splitTopLevel splits s on the commas which are not enclosed in parentheses
+// or quotes, e.g. "a, COALESCE(b, c)" into "a" and "COALESCE(b, c)".
+func splitTopLevel(s string) []string {
+	var (
+		items []string
+		depth int
+		quote rune
+		start int
+	)
+	for i, r := range s {
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			}
+		case r == '\'' || r == '"' || r == '`':
+			quote = r
+		case r == '(':
+			depth++
+		case r == ')':
+			depth--
+		case r == ',' && depth == 0:
+			items = append(items, s[start:i])
+			start = i + 1
+		}
+	}
+	return append(items, s[start:])
+}
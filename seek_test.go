@@ -0,0 +1,57 @@
+package squirrel
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCursorEncodeDecode(t *testing.T) {
+	s, err := Cursor{int64(42), "abc", 1.5, nil}.Encode()
+	assert.NoError(t, err)
+
+	cursor, err := DecodeCursor(s)
+	assert.NoError(t, err)
+	assert.Equal(t, Cursor{int64(42), "abc", 1.5, nil}, cursor)
+
+	_, err = DecodeCursor("not a cursor")
+	assert.Error(t, err)
+}
+
+func TestSplitTopLevel(t *testing.T) {
+	assert.Equal(t, []string{"a", " COALESCE(b, c) DESC", " 'x,y'"}, splitTopLevel("a, COALESCE(b, c) DESC, 'x,y'"))
+}
+
+func TestSeekPredicate(t *testing.T) {
+	tests := []struct {
+		orderBys  []string
+		cursor    Cursor
+		rowValues bool
+		sql       string
+	}{
+		{[]string{"a"}, Cursor{1}, true, "a > ?"},
+		{[]string{"a DESC"}, Cursor{1}, false, "a < ?"},
+		{[]string{"a", "b ASC"}, Cursor{1, 2}, true, "(a, b) > (?,?)"},
+		{[]string{"a desc, b desc"}, Cursor{1, 2}, true, "(a, b) < (?,?)"},
+		{[]string{"a", "b"}, Cursor{1, 2}, false, "(a > ? OR (a = ? AND b > ?))"},
+		{[]string{"a DESC", "b"}, Cursor{1, 2}, true, "(a < ? OR (a = ? AND b > ?))"},
+	}
+	for _, test := range tests {
+		pred, err := seekPredicate(test.orderBys, test.cursor, test.rowValues)
+		assert.NoError(t, err)
+		sql, _, err := pred.ToSql()
+		assert.NoError(t, err)
+		assert.Equal(t, test.sql, sql)
+	}
+}
+
+func TestSeekPredicateErr(t *testing.T) {
+	_, err := seekPredicate(nil, Cursor{1}, true)
+	assert.Error(t, err)
+
+	_, err = seekPredicate([]string{"a", "b"}, Cursor{1}, true)
+	assert.Error(t, err)
+
+	_, err = seekPredicate([]string{"a NULLS LAST"}, Cursor{1}, true)
+	assert.Error(t, err)
+}
@@ -2,25 +2,32 @@ package squirrel
 
 import (
 	"bytes"
+	"context"
+	"database/sql"
 	"fmt"
 	"github.com/lann/builder"
 	"strings"
 )
 
 type selectData struct {
+	statementPolicies
 	PlaceholderFormat PlaceholderFormat
+	Dialect           Dialect
+	RunWith           BaseRunner
 	Prefixes          exprs
 	Options           []string
 	Columns           []Sqlizer
 	From              Sqlizer
 	Joins             []Sqlizer
 	WhereParts        []Sqlizer
+	Seek              Cursor
 	GroupBys          []string
 	HavingParts       []Sqlizer
 	OrderBys          []string
 	Limit             string
 	Offset            string
 	Suffixes          exprs
+	WithDeleted       bool
 }
 
 func (d *selectData) ToSql() (sqlStr string, args []interface{}, err error) {
@@ -52,26 +59,47 @@ func (d *selectData) ToSql() (sqlStr string, args []interface{}, err error) {
 
 	if d.From != nil {
 		sql.WriteString(" FROM ")
-		args, err = appendToSql([]Sqlizer{d.From}, sql, "", args)
+		args, err = appendToSql([]Sqlizer{d.resolveFrom(d.From, true)}, sql, "", args)
 		if err != nil {
 			return
 		}
 	}
 
-	if len(d.Joins) > 0 {
+	joins, scopes, err := d.scopeJoins(d.Joins, d.WithDeleted)
+	if err != nil {
+		return
+	}
+	joins, err = d.resolveJoins(joins)
+	if err != nil {
+		return
+	}
+	if len(joins) > 0 {
 		sql.WriteString(" ")
-		args, err = appendToSql(d.Joins, sql, " ", args)
+		args, err = appendToSql(joins, sql, " ", args)
 		if err != nil {
 			return
 		}
 	}
 
-	if len(d.WhereParts) > 0 {
-		sql.WriteString(" WHERE ")
-		args, err = appendToSql(d.WhereParts, sql, " AND ", args)
+	whereParts := d.WhereParts
+	if len(d.Seek) > 0 {
+		var seek Sqlizer
+		seek, err = seekPredicate(d.OrderBys, d.Seek, d.Dialect.rowValues())
 		if err != nil {
 			return
 		}
+		whereParts = append(whereParts[:len(whereParts):len(whereParts)], seek)
+	}
+
+	fromScopes, err := d.fromScopes(d.From, len(d.Joins) > 0, d.WithDeleted)
+	if err != nil {
+		return
+	}
+	scopes = append(fromScopes, scopes...)
+
+	args, _, err = appendScopedWhereToSql(whereParts, scopes, sql, args)
+	if err != nil {
+		return
 	}
 
 	if len(d.GroupBys) > 0 {
@@ -111,6 +139,67 @@ func (d *selectData) ToSql() (sqlStr string, args []interface{}, err error) {
 	return
 }
 
+func (d *selectData) Exec() (sql.Result, error) {
+	if d.RunWith == nil {
+		return nil, RunnerNotSet
+	}
+	return ExecWith(d.RunWith, d)
+}
+
+func (d *selectData) Query() (*sql.Rows, error) {
+	if d.RunWith == nil {
+		return nil, RunnerNotSet
+	}
+	return QueryWith(d.RunWith, d)
+}
+
+func (d *selectData) QueryRow() RowScanner {
+	if d.RunWith == nil {
+		return &Row{err: RunnerNotSet}
+	}
+	queryRower, ok := d.RunWith.(QueryRower)
+	if !ok {
+		return &Row{err: RunnerNotQueryRunner}
+	}
+	return QueryRowWith(queryRower, d)
+}
+
+func (d *selectData) ExecContext(ctx context.Context) (sql.Result, error) {
+	if d.RunWith == nil {
+		return nil, RunnerNotSet
+	}
+	ctxRunner, ok := d.RunWith.(ExecerContext)
+	if !ok {
+		return nil, NoContextSupport
+	}
+	return ExecContextWith(ctx, ctxRunner, d)
+}
+
+func (d *selectData) QueryContext(ctx context.Context) (*sql.Rows, error) {
+	if d.RunWith == nil {
+		return nil, RunnerNotSet
+	}
+	ctxRunner, ok := d.RunWith.(QueryerContext)
+	if !ok {
+		return nil, NoContextSupport
+	}
+	return QueryContextWith(ctx, ctxRunner, d)
+}
+
+func (d *selectData) QueryRowContext(ctx context.Context) RowScanner {
+	if d.RunWith == nil {
+		return &Row{err: RunnerNotSet}
+	}
+	queryRower, ok := d.RunWith.(QueryRowerContext)
+	if !ok {
+		if _, ok := d.RunWith.(QueryerContext); !ok {
+			return &Row{err: RunnerNotQueryRunner}
+		}
+		return &Row{err: NoContextSupport}
+	}
+	return QueryRowContextWith(ctx, queryRower, d)
+}
+
 // Builder
 
 // SelectBuilder builds SQL SELECT statements.
@@ -124,10 +213,98 @@ func init() {
 
 // PlaceholderFormat sets PlaceholderFormat (e.g. Question or Dollar) for the
 // query.
-func (b SelectBuilder) PlaceholderFormat(f PlaceholderFormat) WhereConditions {
+func (b SelectBuilder) PlaceholderFormat(f PlaceholderFormat) SelectBuilder {
 	return builder.Set(b, "PlaceholderFormat", f).(SelectBuilder)
 }
 
+// Dialect sets the Dialect (e.g. MySQL or PostgreSQL) the query is rendered
+// for.
+func (b SelectBuilder) Dialect(d Dialect) SelectBuilder {
+	return builder.Set(b, "Dialect", d).(SelectBuilder)
+}
+
+// Runner methods
+
+// RunWith sets a Runner (like database/sql.DB) to be used with e.g. Exec.
+// For most cases runner will be a database connection.
+//
+// Internally we use this to mock out the database connection for testing.
+func (b SelectBuilder) RunWith(runner BaseRunner) SelectBuilder {
+	return setRunWith(b, runner).(SelectBuilder)
+}
+
+// Exec builds and Execs the query with the Runner set by RunWith.
+func (b SelectBuilder) Exec() (sql.Result, error) {
+	data := builder.GetStruct(b).(selectData)
+	return data.Exec()
+}
+
+// Query builds and Querys the query with the Runner set by RunWith.
+func (b SelectBuilder) Query() (*sql.Rows, error) {
+	data := builder.GetStruct(b).(selectData)
+	return data.Query()
+}
+
+// QueryRow builds and QueryRows the query with the Runner set by RunWith.
+func (b SelectBuilder) QueryRow() RowScanner {
+	data := builder.GetStruct(b).(selectData)
+	return data.QueryRow()
+}
+
+// Scan is a shortcut for QueryRow().Scan.
+func (b SelectBuilder) Scan(dest ...interface{}) error {
+	return b.QueryRow().Scan(dest...)
+}
+
+// ExecContext builds and ExecContexts the query with the Runner set by RunWith.
+func (b SelectBuilder) ExecContext(ctx context.Context) (sql.Result, error) {
+	data := builder.GetStruct(b).(selectData)
+	return data.ExecContext(ctx)
+}
+
+// QueryContext builds and QueryContexts the query with the Runner set by
+// RunWith.
+func (b SelectBuilder) QueryContext(ctx context.Context) (*sql.Rows, error) {
+	data := builder.GetStruct(b).(selectData)
+	return data.QueryContext(ctx)
+}
+
+// QueryRowContext builds and QueryRowContexts the query with the Runner set by
+// RunWith.
+func (b SelectBuilder) QueryRowContext(ctx context.Context) RowScanner {
+	data := builder.GetStruct(b).(selectData)
+	return data.QueryRowContext(ctx)
+}
+
+// ScanContext is a shortcut for QueryRowContext().Scan.
+func (b SelectBuilder) ScanContext(ctx context.Context, dest ...interface{}) error {
+	return b.QueryRowContext(ctx).Scan(dest...)
+}
+
+// ScanStruct runs the query with the Runner set by RunWith and scans the first
+// row into dest, a pointer to a struct. Columns are mapped to fields by their
+// db tag; use pointer or sql.Null* fields for nullable columns. It returns
+// sql.ErrNoRows if the query returns no rows.
+func (b SelectBuilder) ScanStruct(ctx context.Context, dest interface{}) error {
+	rows, err := b.QueryContext(ctx)
+	if err != nil {
+		return err
+	}
+	return scanOne(rows, dest)
+}
+
+// ScanAll runs the query with the Runner set by RunWith and appends every row
+// to dest, a pointer to a slice of structs or of pointers to structs mapped
+// as in ScanStruct. A slice of any other type is scanned from a single
+// column.
+func (b SelectBuilder) ScanAll(ctx context.Context, dest interface{}) error {
+	rows, err := b.QueryContext(ctx)
+	if err != nil {
+		return err
+	}
+	return scanAll(rows, dest)
+}
+
 // SQL methods
 
 // ToSql builds the query into a SQL string and bound args.
@@ -137,22 +314,22 @@ func (b SelectBuilder) ToSql() (string, []interface{}, error) {
 }
 
 // Prefix adds an expression to the beginning of the query
-func (b SelectBuilder) Prefix(sql string, args ...interface{}) SelectCondition {
+func (b SelectBuilder) Prefix(sql string, args ...interface{}) SelectBuilder {
 	return builder.Append(b, "Prefixes", Expr(sql, args...)).(SelectBuilder)
 }
 
 // Distinct adds a DISTINCT clause to the query.
-func (b SelectBuilder) Distinct() SelectCondition {
+func (b SelectBuilder) Distinct() SelectBuilder {
 	return b.Options("DISTINCT")
 }
 
 // Options adds select option to the query
-func (b SelectBuilder) Options(options ...string) SelectCondition {
+func (b SelectBuilder) Options(options ...string) SelectBuilder {
 	return builder.Extend(b, "Options", options).(SelectBuilder)
 }
 
 // Columns adds result columns to the query.
-func (b SelectBuilder) Columns(columns ...string) SelectCondition {
+func (b SelectBuilder) Columns(columns ...string) SelectBuilder {
 	var parts []interface{}
 	for _, str := range columns {
 		parts = append(parts, newPart(str))
@@ -160,41 +337,58 @@ func (b SelectBuilder) Columns(columns ...string) SelectCondition {
 	return builder.Extend(b, "Columns", parts).(SelectBuilder)
 }
 
+// ColumnsOf adds the columns mapped by the db tags of the struct v to the
+// query, qualified with alias unless it is empty. Tagged struct fields hold the
+// rows of joined tables and add their columns qualified with the tag:
+//
+//	type Post struct {
+//	    ID     int64  `db:"id"`
+//	    Author Author `db:"author"`
+//	}
+//	Select().ColumnsOf(Post{}, "p").
+//	    From("posts p").Join("authors author ON author.id = p.author_id")
+//	// SELECT p.id, author.id AS "author.id", author.name AS "author.name" ...
+//
+// ScanStruct and ScanAll map these columns back to the struct.
+func (b SelectBuilder) ColumnsOf(v interface{}, alias string) SelectBuilder {
+	return b.Columns(structSelectColumns(v, alias)...)
+}
+
 // Column adds a result column to the query.
 // Unlike Columns, Column accepts args which will be bound to placeholders in
 // the columns string, for example:
 //   Column("IF(col IN ("+squirrel.Placeholders(3)+"), 1, 0) as col", 1, 2, 3)
-func (b SelectBuilder) Column(column interface{}, args ...interface{}) SelectCondition {
+func (b SelectBuilder) Column(column interface{}, args ...interface{}) SelectBuilder {
 	return builder.Append(b, "Columns", newPart(column, args...)).(SelectBuilder)
 }
 
 // From sets the FROM clause of the query.
-func (b SelectBuilder) From(from string) SelectCondition {
+func (b SelectBuilder) From(from string) SelectBuilder {
 	return builder.Set(b, "From", newPart(from)).(SelectBuilder)
 }
 
 // FromSelect sets a subquery into the FROM clause of the query.
-func (b SelectBuilder) FromSelect(from SelectCondition, alias string) SelectCondition {
+func (b SelectBuilder) FromSelect(from SelectCondition, alias string) SelectBuilder {
 	return builder.Set(b, "From", Alias(from, alias)).(SelectBuilder)
 }
 
 // JoinClause adds a join clause to the query.
-func (b SelectBuilder) JoinClause(pred interface{}, args ...interface{}) JoinCondition {
+func (b SelectBuilder) JoinClause(pred interface{}, args ...interface{}) SelectBuilder {
 	return builder.Append(b, "Joins", newPart(pred, args...)).(SelectBuilder)
 }
 
 // Join adds a JOIN clause to the query.
-func (b SelectBuilder) Join(join string, rest ...interface{}) JoinCondition {
+func (b SelectBuilder) Join(join string, rest ...interface{}) SelectBuilder {
 	return b.JoinClause("JOIN "+join, rest...)
 }
 
 // LeftJoin adds a LEFT JOIN clause to the query.
-func (b SelectBuilder) LeftJoin(join string, rest ...interface{}) JoinCondition {
+func (b SelectBuilder) LeftJoin(join string, rest ...interface{}) SelectBuilder {
 	return b.JoinClause("LEFT JOIN "+join, rest...)
 }
 
 // RightJoin adds a RIGHT JOIN clause to the query.
-func (b SelectBuilder) RightJoin(join string, rest ...interface{}) JoinCondition {
+func (b SelectBuilder) RightJoin(join string, rest ...interface{}) SelectBuilder {
 	return b.JoinClause("RIGHT JOIN "+join, rest...)
 }
 
@@ -218,77 +412,179 @@ func (b SelectBuilder) RightJoin(join string, rest ...interface{}) JoinCondition
 // are ANDed together.
 //
 // Where will panic if pred isn't any of the above types.
-func (b SelectBuilder) Where(pred interface{}, args ...interface{}) WhereConditions {
+func (b SelectBuilder) Where(pred interface{}, args ...interface{}) SelectBuilder {
 	return builder.Append(b, "WhereParts", newWherePart(pred, args...)).(SelectBuilder)
 }
 
 //Condition
-func (b SelectBuilder) Condition() WhereConditions {
+func (b SelectBuilder) Condition() SelectBuilder {
 	return builder.Append(b, "WhereParts", newWherePart("")).(SelectBuilder)
 }
 
 //expr
-func (b SelectBuilder) Expr(sql string, args ...interface{}) WhereConditions {
+func (b SelectBuilder) Expr(sql string, args ...interface{}) SelectBuilder {
 	return builder.Append(b, "WhereParts", newWherePart(expr{sql: sql, args: args})).(SelectBuilder)
 }
 
 //eq
-func (b SelectBuilder) Eq(column string, arg interface{}) WhereConditions {
+func (b SelectBuilder) Eq(column string, arg interface{}) SelectBuilder {
 	return b.Where(Eq{column: arg})
 }
 
-func (b SelectBuilder) NotEq(column string, arg interface{}) WhereConditions {
+func (b SelectBuilder) NotEq(column string, arg interface{}) SelectBuilder {
 	return b.Where(NotEq{column: arg})
 }
 
 //gt
-func (b SelectBuilder) Gt(column string, arg interface{}) WhereConditions {
+func (b SelectBuilder) Gt(column string, arg interface{}) SelectBuilder {
 	return b.Where(Gt{column: arg})
 }
 
 //gtOrEq
-func (b SelectBuilder) GtOrEq(column string, arg interface{}) WhereConditions {
+func (b SelectBuilder) GtOrEq(column string, arg interface{}) SelectBuilder {
 	return b.Where(GtOrEq{column: arg})
 }
 
 //lt
-func (b SelectBuilder) Lt(column string, arg interface{}) WhereConditions {
+func (b SelectBuilder) Lt(column string, arg interface{}) SelectBuilder {
 	return b.Where(Lt{column: arg})
 }
 
 //ltOrEq
-func (b SelectBuilder) LtOrEq(column string, arg interface{}) WhereConditions {
+func (b SelectBuilder) LtOrEq(column string, arg interface{}) SelectBuilder {
 	return b.Where(LtOrEq{column: arg})
 }
 
 // GroupBy adds GROUP BY expressions to the query.
-func (b SelectBuilder) GroupBy(groupBys ...string) WhereConditions {
+func (b SelectBuilder) GroupBy(groupBys ...string) SelectBuilder {
 	return builder.Extend(b, "GroupBys", groupBys).(SelectBuilder)
 }
 
 // Having adds an expression to the HAVING clause of the query.
 //
 // See Where.
-func (b SelectBuilder) Having(pred interface{}, rest ...interface{}) WhereConditions {
+func (b SelectBuilder) Having(pred interface{}, rest ...interface{}) SelectBuilder {
 	return builder.Append(b, "HavingParts", newWherePart(pred, rest...)).(SelectBuilder)
 }
 
 // OrderBy adds ORDER BY expressions to the query.
-func (b SelectBuilder) OrderBy(orderBys ...string) WhereConditions {
+func (b SelectBuilder) OrderBy(orderBys ...string) SelectBuilder {
 	return builder.Extend(b, "OrderBys", orderBys).(SelectBuilder)
 }
 
+// SeekAfter restricts the query to the rows following cursor in the order
+// given by the ORDER BY clause, for keyset (seek) pagination. The cursor holds
+// the ORDER BY values of the last row of the previous page; an empty cursor
+// selects the first page.
+//
+// The condition honours the direction of each ORDER BY column. When all of
+// them sort the same way and the Dialect supports row values it is rendered as
+//
+//	(a, b) > (?, ?)
+//
+// otherwise as
+//
+//	(a > ? OR (a = ? AND b > ?))
+func (b SelectBuilder) SeekAfter(cursor Cursor) SelectBuilder {
+	return builder.Set(b, "Seek", cursor).(SelectBuilder)
+}
+
 // Limit sets a LIMIT clause on the query.
-func (b SelectBuilder) Limit(limit int) WhereConditions {
+func (b SelectBuilder) Limit(limit int) SelectBuilder {
 	return builder.Set(b, "Limit", fmt.Sprintf("%d", limit)).(SelectBuilder)
 }
 
 // Offset sets a OFFSET clause on the query.
-func (b SelectBuilder) Offset(offset int) WhereConditions {
+func (b SelectBuilder) Offset(offset int) SelectBuilder {
 	return builder.Set(b, "Offset", fmt.Sprintf("%d", offset)).(SelectBuilder)
 }
 
 // Suffix adds an expression to the end of the query
-func (b SelectBuilder) Suffix(sql string, args ...interface{}) WhereConditions {
+func (b SelectBuilder) Suffix(sql string, args ...interface{}) SelectBuilder {
 	return builder.Append(b, "Suffixes", Expr(sql, args...)).(SelectBuilder)
 }
+
+// RemoveColumns removes all result columns from the query.
+func (b SelectBuilder) RemoveColumns() SelectBuilder {
+	return builder.Delete(b, "Columns").(SelectBuilder)
+}
+
+// ReplaceColumns replaces the result columns of the query.
+func (b SelectBuilder) ReplaceColumns(columns ...string) SelectBuilder {
+	return b.RemoveColumns().Columns(columns...)
+}
+
+// WithDeleted includes the soft deleted rows of the From and joined tables.
+//
+// See StatementBuilderType.SoftDelete.
+func (b SelectBuilder) WithDeleted() SelectBuilder {
+	return builder.Set(b, "WithDeleted", true).(SelectBuilder)
+}
+
+// ClearWhere removes all WHERE expressions from the query.
+func (b SelectBuilder) ClearWhere() SelectBuilder {
+	return builder.Delete(b, "WhereParts").(SelectBuilder)
+}
+
+// RemoveOrderBy removes the ORDER BY clause from the query.
+func (b SelectBuilder) RemoveOrderBy() SelectBuilder {
+	return builder.Delete(b, "OrderBys").(SelectBuilder)
+}
+
+// RemoveLimit removes the LIMIT clause from the query.
+func (b SelectBuilder) RemoveLimit() SelectBuilder {
+	return builder.Delete(b, "Limit").(SelectBuilder)
+}
+
+// RemoveOffset removes the OFFSET clause from the query.
+func (b SelectBuilder) RemoveOffset() SelectBuilder {
+	return builder.Delete(b, "Offset").(SelectBuilder)
+}
+
+// Merge adds the joins, WHERE, GROUP BY, HAVING, ORDER BY, LIMIT, OFFSET and
+// suffix parts of a fragment built with Where, Condition or Join to the query.
+//
+// Ex:
+//
+//	tenant := sq.Where("tenant_id = ?", id)
+//	sq.Select("*").From("orders").Merge(tenant)
+func (b SelectBuilder) Merge(fragment ConditionFragment) SelectBuilder {
+	return mergeFragment(b, fragment).(SelectBuilder)
+}
+
+// CountQuery returns a query counting the rows this query would return,
+// without its ORDER BY, LIMIT and OFFSET clauses and SeekAfter cursor.
+//
+// Queries using GROUP BY or DISTINCT are wrapped in a subquery:
+//
+//	SELECT COUNT(*) FROM (SELECT DISTINCT a FROM b) AS t
+//
+// All other queries have their result columns replaced with COUNT(*).
+func (b SelectBuilder) CountQuery() SelectBuilder {
+	b = b.RemoveOrderBy().RemoveLimit().RemoveOffset().SeekAfter(nil)
+
+	data := builder.GetStruct(b).(selectData)
+	if len(data.GroupBys) == 0 && !hasDistinct(data.Options) {
+		return b.ReplaceColumns("COUNT(*)")
+	}
+
+	// The subquery keeps everything but the prefixes, which must stay in front
+	// of the whole statement, and is rendered with plain question marks so the
+	// outer query numbers the placeholders. The subquery is scoped by the
+	// statement policies, the outer query has nothing left to scope.
+	inner := builder.Delete(b, "Prefixes").(SelectBuilder).PlaceholderFormat(Question)
+	outer := b
+	for _, key := range []string{"Options", "Columns", "Joins", "WhereParts", "GroupBys", "HavingParts", "Suffixes", "Tenant", "SoftDelete"} {
+		outer = builder.Delete(outer, key).(SelectBuilder)
+	}
+	return builder.Set(outer, "From", Alias(inner, "t")).(SelectBuilder).Columns("COUNT(*)")
+}
+
+func hasDistinct(options []string) bool {
+	for _, option := range options {
+		if strings.HasPrefix(strings.ToUpper(option), "DISTINCT") {
+			return true
+		}
+	}
+	return false
+}
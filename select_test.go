@@ -83,37 +83,37 @@ func TestSelectBuilderPlaceholders(t *testing.T) {
 	assert.Equal(t, "SELECT test WHERE x = $1 AND y = $2", sql)
 }
 
-//func TestSelectBuilderRunners(t *testing.T) {
-//	db := &DBStub{}
-//	b := Select("test").RunWith(db)
-//
-//	expectedSql := "SELECT test"
-//
-//	b.Exec()
-//	assert.Equal(t, expectedSql, db.LastExecSql)
-//
-//	b.Query()
-//	assert.Equal(t, expectedSql, db.LastQuerySql)
-//
-//	b.QueryRow()
-//	assert.Equal(t, expectedSql, db.LastQueryRowSql)
-//
-//	err := b.Scan()
-//	assert.NoError(t, err)
-//}
+func TestSelectBuilderRunners(t *testing.T) {
+	db := &DBStub{}
+	b := Select("test").RunWith(db)
 
-//func TestSelectBuilderNoRunner(t *testing.T) {
-//	b := Select("test")
-//
-//	_, err := b.Exec()
-//	assert.Equal(t, RunnerNotSet, err)
-//
-//	_, err = b.Query()
-//	assert.Equal(t, RunnerNotSet, err)
-//
-//	err = b.Scan()
-//	assert.Equal(t, RunnerNotSet, err)
-//}
+	expectedSql := "SELECT test"
+
+	b.Exec()
+	assert.Equal(t, expectedSql, db.LastExecSql)
+
+	b.Query()
+	assert.Equal(t, expectedSql, db.LastQuerySql)
+
+	b.QueryRow()
+	assert.Equal(t, expectedSql, db.LastQueryRowSql)
+
+	err := b.Scan()
+	assert.NoError(t, err)
+}
+
+func TestSelectBuilderNoRunner(t *testing.T) {
+	b := Select("test")
+
+	_, err := b.Exec()
+	assert.Equal(t, RunnerNotSet, err)
+
+	_, err = b.Query()
+	assert.Equal(t, RunnerNotSet, err)
+
+	err = b.Scan()
+	assert.Equal(t, RunnerNotSet, err)
+}
 
 func TestSelectBuilderSimpleJoin(t *testing.T) {
 
@@ -165,3 +165,98 @@ func TestSelectWithOptions(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, "SELECT DISTINCT SQL_NO_CACHE * FROM foo", sql)
 }
+
+func TestSelectBuilderMerge(t *testing.T) {
+	tenant := Join("b ON b.id = a.b_id").Where("a.tenant_id = ?", 1).OrderBy("a.id").Limit(10)
+	b := Select("a.*").From("a").Where("a.x = ?", 2).Merge(tenant).Offset(20)
+
+	sql, args, err := b.ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT a.* FROM a JOIN b ON b.id = a.b_id WHERE a.x = ? AND a.tenant_id = ? ORDER BY a.id LIMIT 10 OFFSET 20", sql)
+	assert.Equal(t, []interface{}{2, 1}, args)
+}
+
+func TestSelectBuilderCountQuery(t *testing.T) {
+	b := Select("a", "b").From("c").Where("d = ?", 1).OrderBy("a").Limit(10).Offset(20)
+
+	sql, args, err := b.CountQuery().ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT COUNT(*) FROM c WHERE d = ?", sql)
+	assert.Equal(t, []interface{}{1}, args)
+}
+
+func TestSelectBuilderCountQueryWrapped(t *testing.T) {
+	b := Select("a").
+		Prefix("WITH p AS (SELECT ?)", 0).
+		From("c").
+		Where("d = ?", 1).
+		GroupBy("a").
+		Having("COUNT(*) > ?", 2).
+		OrderBy("a").
+		Limit(10).
+		PlaceholderFormat(Dollar)
+
+	sql, args, err := b.CountQuery().ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "WITH p AS (SELECT $1) SELECT COUNT(*) FROM (SELECT a FROM c WHERE d = $2 GROUP BY a HAVING COUNT(*) > $3) AS t", sql)
+	assert.Equal(t, []interface{}{0, 1, 2}, args)
+
+	sql, _, err = Select("a").Distinct().From("c").CountQuery().ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT COUNT(*) FROM (SELECT DISTINCT a FROM c) AS t", sql)
+}
+
+func TestSelectBuilderSeekAfter(t *testing.T) {
+	b := Select("*").From("a").Where("b = ?", 1).SeekAfter(Cursor{"2018-01-01", 10}).OrderBy("c DESC", "id").Limit(20)
+
+	sql, args, err := b.ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT * FROM a WHERE b = ? AND (c < ? OR (c = ? AND id > ?)) ORDER BY c DESC, id LIMIT 20", sql)
+	assert.Equal(t, []interface{}{1, "2018-01-01", "2018-01-01", 10}, args)
+
+	sql, args, err = Select("*").From("a").OrderBy("c", "id").SeekAfter(Cursor{"2018-01-01", 10}).Dialect(PostgreSQL).ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT * FROM a WHERE (c, id) > (?,?) ORDER BY c, id", sql)
+	assert.Equal(t, []interface{}{"2018-01-01", 10}, args)
+
+	sql, _, err = b.SeekAfter(nil).ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT * FROM a WHERE b = ? ORDER BY c DESC, id LIMIT 20", sql)
+}
+
+func TestSelectBuilderRemoveClauses(t *testing.T) {
+	b := Select("a", "b").From("c").Where("d = ?", 1).OrderBy("a").Limit(10).Offset(20)
+
+	sql, args, err := b.ReplaceColumns("1").ClearWhere().RemoveOrderBy().RemoveLimit().RemoveOffset().ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT 1 FROM c", sql)
+	assert.Empty(t, args)
+
+	_, _, err = b.RemoveColumns().ToSql()
+	assert.Error(t, err)
+
+	sql, _, err = b.ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT a, b FROM c WHERE d = ? ORDER BY a LIMIT 10 OFFSET 20", sql)
+}
+
+func TestSelectStruct(t *testing.T) {
+	sql, _, err := SelectStruct(testAuthor{}, "a").From("authors a").ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT a.id, a.name FROM authors a", sql)
+
+	sql, _, err = Select("COUNT(*) OVER ()").ColumnsOf(testAuthor{}, "").From("authors").ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT COUNT(*) OVER (), id, name FROM authors", sql)
+}
+
+func TestSelectBuilderEmptyWhereParts(t *testing.T) {
+	sql, args, err := Select("a").From("b").Where("c = ?", 1).Where("").ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT a FROM b WHERE c = ?", sql)
+	assert.Equal(t, []interface{}{1}, args)
+
+	sql, _, err = StatementBuilder.RequireWhere(true).Select("a").From("b").ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT a FROM b", sql)
+}
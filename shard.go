@@ -0,0 +1,108 @@
+package squirrel
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/lann/builder"
+)
+
+// TableResolver maps a logical table name to the physical table holding the
+// rows of a statement, e.g. "orders" to "orders_07". It returns table as is
+// for tables that are not sharded.
+type TableResolver func(table string) string
+
+// TableResolver makes the statements built from this StatementBuilderType
+// resolve the tables they name in From, Into, Table, Delete, Using and Join
+// with r. Policies like WithTenant keep matching the logical names. A table
+// without an alias is aliased with its logical name, so that columns
+// qualified with it still resolve, except in inserts, in SQLServer single
+// table updates and in MySQL and SQLServer single table deletes, which cannot
+// alias their table.
+//
+// Ex:
+//
+//	sb := StatementBuilder.TableResolver(ShardByModulo(16, userID, "orders"))
+//	sb.Select("*").From("orders").Where("orders.user_id = ?", userID)
+//	// SELECT * FROM orders_07 AS orders WHERE orders.user_id = ?
+func (b StatementBuilderType) TableResolver(r TableResolver) StatementBuilderType {
+	return builder.Set(b, "TableResolver", r).(StatementBuilderType)
+}
+
+// ShardByModulo returns a TableResolver routing tables to the shard key
+// modulo shards, appended to the table name padded to the width of the last
+// shard: with 16 shards the key 23 routes "orders" to "orders_07". Only
+// tables are sharded, or every table without tables.
+func ShardByModulo(shards int, key int64, tables ...string) TableResolver {
+	if shards <= 0 {
+		panic(fmt.Sprintf("ShardByModulo needs a positive number of shards, not %d", shards))
+	}
+	n := int64(shards)
+	shard := (key%n + n) % n
+	width := len(strconv.Itoa(shards - 1))
+	return func(table string) string {
+		if !appliesTo(tables, table) {
+			return table
+		}
+		return fmt.Sprintf("%s_%0*d", table, width, shard)
+	}
+}
+
+// resolveTable returns the table expression table with its table resolved by
+// the TableResolver. alias adds the logical name as alias to a table without
+// one.
+func (p statementPolicies) resolveTable(table string, alias bool) string {
+	if p.TableResolver == nil {
+		return table
+	}
+	name := tableName(table)
+	physical := p.TableResolver(name)
+	if physical == name {
+		return table
+	}
+	i := strings.Index(table, name)
+	resolved := table[:i] + physical + table[i+len(name):]
+	if alias && len(strings.Fields(table)) == 1 {
+		resolved += " AS " + name
+	}
+	return resolved
+}
+
+// resolveFrom resolves the table set by From, if From is not a subquery.
+func (p statementPolicies) resolveFrom(from Sqlizer, alias bool) Sqlizer {
+	if table, ok := fromTable(from); ok && p.TableResolver != nil {
+		return newPart(p.resolveTable(table, alias))
+	}
+	return from
+}
+
+// resolveJoins resolves the table joined by each of joins, aliased with its
+// logical name unless the join names an alias.
+func (p statementPolicies) resolveJoins(joins []Sqlizer) ([]Sqlizer, error) {
+	if p.TableResolver == nil {
+		return joins, nil
+	}
+	resolved := make([]Sqlizer, len(joins))
+	for i, join := range joins {
+		resolved[i] = join
+		sql, args, err := join.ToSql()
+		if err != nil {
+			return nil, err
+		}
+		m := joinTableRegexp.FindStringSubmatchIndex(sql)
+		if m == nil {
+			continue
+		}
+		table := sql[m[6]:m[7]]
+		physical := p.TableResolver(table)
+		if physical == table {
+			continue
+		}
+		if m[8] < 0 || isJoinKeyword(sql[m[8]:m[9]]) {
+			physical += " AS " + table
+		}
+		resolved[i] = Expr(sql[:m[6]]+physical+sql[m[7]:], args...)
+	}
+	return resolved, nil
+}
@@ -0,0 +1,94 @@
+package squirrel
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShardByModulo(t *testing.T) {
+	r := ShardByModulo(16, 23, "orders")
+	assert.Equal(t, "orders_07", r("orders"))
+	assert.Equal(t, "users", r("users"))
+
+	assert.Equal(t, "orders_15", ShardByModulo(16, -1)("orders"))
+	assert.Equal(t, "orders_003", ShardByModulo(1000, 2003)("orders"))
+
+	assert.Panics(t, func() { ShardByModulo(0, 1) })
+}
+
+func TestTableResolver(t *testing.T) {
+	sb := StatementBuilder.TableResolver(ShardByModulo(16, 23, "orders", "items"))
+
+	sql, _, err := sb.Select("orders.id").From("orders").Where("orders.user_id = ?", 23).ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT orders.id FROM orders_07 AS orders WHERE orders.user_id = ?", sql)
+
+	sql, _, err = sb.Select("orders.id", "i.sku").
+		From("orders").
+		Join("items AS i ON i.order_id = orders.id").
+		LeftJoin("users ON users.id = orders.user_id").
+		ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT orders.id, i.sku FROM orders_07 AS orders "+
+		"JOIN items_07 AS i ON i.order_id = orders.id "+
+		"LEFT JOIN users ON users.id = orders.user_id", sql)
+
+	sql, _, err = sb.Insert("orders").Columns("user_id").Values(23).ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "INSERT INTO orders_07 (user_id) VALUES (?)", sql)
+
+	sql, _, err = sb.Update("orders").Set("paid", true).Where("id = ?", 1).ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "UPDATE orders_07 AS orders SET paid = ? WHERE id = ?", sql)
+
+	sql, _, err = sb.Update("orders").Set("paid", true).Where("id = ?", 1).Dialect(SQLServer).ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "UPDATE orders_07 SET paid = ? WHERE id = ?", sql)
+
+	sql, _, err = sb.Update("orders").Set("paid", true).
+		Join("items ON items.order_id = orders.id").
+		Dialect(SQLServer).
+		ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "UPDATE orders SET paid = ? FROM orders_07 AS orders "+
+		"JOIN items_07 AS items ON items.order_id = orders.id", sql)
+
+	sql, _, err = sb.Delete("orders").Where("id = ?", 1).ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "DELETE FROM orders_07 AS orders WHERE id = ?", sql)
+
+	sql, _, err = sb.Delete("orders").Where("id = ?", 1).Dialect(MySQL).ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "DELETE FROM orders_07 WHERE id = ?", sql)
+
+	sql, _, err = sb.Delete("orders").Join("items ON items.order_id = orders.id").Dialect(MySQL).ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "DELETE orders FROM orders_07 AS orders "+
+		"JOIN items_07 AS items ON items.order_id = orders.id", sql)
+
+	sql, _, err = sb.Delete("items").Using("orders o").
+		Where("items.order_id = o.id AND o.user_id = ?", 23).
+		Dialect(PostgreSQL).
+		ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "DELETE FROM items_07 AS items USING orders_07 o "+
+		"WHERE items.order_id = o.id AND o.user_id = ?", sql)
+}
+
+func TestTableResolverWithTenant(t *testing.T) {
+	sb := StatementBuilder.
+		TableResolver(ShardByModulo(16, 23, "orders")).
+		WithTenant("tenant_id", 7, "orders")
+
+	sql, args, err := sb.Select("*").From("orders").Join("users u ON u.id = orders.user_id").ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT * FROM orders_07 AS orders JOIN users u ON u.id = orders.user_id "+
+		"WHERE orders.tenant_id = ?", sql)
+	assert.Equal(t, []interface{}{7}, args)
+
+	sql, args, err = sb.Insert("orders").Columns("user_id").Values(23).ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "INSERT INTO orders_07 (user_id,tenant_id) VALUES (?,?)", sql)
+	assert.Equal(t, []interface{}{23, 7}, args)
+}
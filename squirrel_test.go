@@ -1,11 +1,69 @@
 package squirrel
 
 import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
 	"strings"
 	"testing"
 	"github.com/stretchr/testify/assert"
 )
 
+type DBStub struct {
+	err error
+
+	LastExecSql  string
+	LastExecArgs []interface{}
+	ExecCount    int
+	RowsAffected int64
+
+	LastQuerySql  string
+	LastQueryArgs []interface{}
+
+	LastQueryRowSql  string
+	LastQueryRowArgs []interface{}
+}
+
+func (s *DBStub) Exec(query string, args ...interface{}) (sql.Result, error) {
+	s.LastExecSql = query
+	s.LastExecArgs = args
+	s.ExecCount++
+	return driver.RowsAffected(s.RowsAffected), s.err
+}
+
+func (s *DBStub) Query(query string, args ...interface{}) (*sql.Rows, error) {
+	s.LastQuerySql = query
+	s.LastQueryArgs = args
+	return nil, s.err
+}
+
+func (s *DBStub) QueryRow(query string, args ...interface{}) RowScanner {
+	s.LastQueryRowSql = query
+	s.LastQueryRowArgs = args
+	return &Row{RowScanner: &RowStub{}, err: s.err}
+}
+
+func (s *DBStub) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	return s.Exec(query, args...)
+}
+
+func (s *DBStub) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	return s.Query(query, args...)
+}
+
+func (s *DBStub) QueryRowContext(ctx context.Context, query string, args ...interface{}) RowScanner {
+	return s.QueryRow(query, args...)
+}
+
+type RowStub struct {
+	Scanned bool
+}
+
+func (r *RowStub) Scan(_ ...interface{}) error {
+	r.Scanned = true
+	return nil
+}
+
 func TestDebugSqlizer(t *testing.T) {
 	sqlizer := Expr("x = ? AND y = ? AND z = '??'", 1, "text")
 	expectedDebug := "x = '1' AND y = 'text' AND z = '?'"
@@ -22,3 +80,20 @@ func TestDebugSqlizerErrors(t *testing.T) {
 	errorMsg = DebugSqlizer(Lt{"x": nil}) // Cannot use nil values with Lt
 	assert.True(t, strings.HasPrefix(errorMsg, "[ToSql error: "))
 }
+
+func TestExecWith(t *testing.T) {
+	db := &DBStub{}
+	ExecWith(db, Insert("t").Values(1))
+	assert.Equal(t, "INSERT INTO t VALUES (?)", db.LastExecSql)
+	assert.Equal(t, []interface{}{1}, db.LastExecArgs)
+
+	_, err := ExecWith(db, Insert("t"))
+	assert.Error(t, err)
+}
+
+func TestQueryRowWithErr(t *testing.T) {
+	db := &DBStub{}
+	err := QueryRowWith(db, Select()).Scan()
+	assert.Error(t, err)
+	assert.Equal(t, "", db.LastQueryRowSql)
+}
@@ -0,0 +1,231 @@
+package squirrel
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+)
+
+// structField is a struct field mapped to a column by its db tag:
+//
+//	ID        int64     `db:"id,pk,omitempty"`
+//	Name      string    `db:"name"`
+//	CreatedAt time.Time `db:"created_at,readonly"`
+//
+// omitempty skips the field when it holds its zero value, readonly skips it
+// in inserts and updates and pk excludes it from the SET clause of updates.
+// Fields without a db tag or tagged "-" are ignored, except for embedded
+// structs whose fields are mapped as if they belonged to the outer struct.
+// A tagged struct field holds the row of a joined table: its fields are
+// mapped to columns prefixed with the tag, as in "author.id", and are only
+// used when selecting and scanning.
+type structField struct {
+	column    string
+	index     []int
+	omitEmpty bool
+	readOnly  bool
+	pk        bool
+	joined    bool
+}
+
+var structFieldsCache sync.Map // map[reflect.Type][]structField
+
+// structFields returns the mapped fields of the struct type t in declaration
+// order.
+func structFields(t reflect.Type) []structField {
+	if fields, ok := structFieldsCache.Load(t); ok {
+		return fields.([]structField)
+	}
+	fields := appendStructFields(nil, t, nil, "")
+	structFieldsCache.Store(t, fields)
+	return fields
+}
+
+var (
+	scannerType = reflect.TypeOf((*sql.Scanner)(nil)).Elem()
+	valuerType  = reflect.TypeOf((*driver.Valuer)(nil)).Elem()
+	timeType    = reflect.TypeOf(time.Time{})
+)
+
+// isRowStruct reports whether t is a struct mapped field by field rather than
+// a single column value like time.Time or a sql.Scanner.
+func isRowStruct(t reflect.Type) bool {
+	return t.Kind() == reflect.Struct &&
+		t != timeType &&
+		!t.Implements(valuerType) &&
+		!reflect.PtrTo(t).Implements(scannerType)
+}
+
+func appendStructFields(fields []structField, t reflect.Type, index []int, prefix string) []structField {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag, tagged := f.Tag.Lookup("db")
+		if tag == "-" {
+			continue
+		}
+
+		fieldIndex := append(index[:len(index):len(index)], i)
+
+		if !tagged {
+			ft := f.Type
+			if ft.Kind() == reflect.Ptr {
+				ft = ft.Elem()
+			}
+			if f.Anonymous && ft.Kind() == reflect.Struct {
+				fields = appendStructFields(fields, ft, fieldIndex, prefix)
+			}
+			continue
+		}
+		if f.PkgPath != "" {
+			continue
+		}
+
+		options := strings.Split(tag, ",")
+		field := structField{column: options[0], index: fieldIndex, joined: prefix != ""}
+		if field.column == "" {
+			field.column = strings.ToLower(f.Name)
+		}
+		field.column = prefix + field.column
+
+		ft := f.Type
+		if ft.Kind() == reflect.Ptr {
+			ft = ft.Elem()
+		}
+		if isRowStruct(ft) {
+			fields = appendStructFields(fields, ft, fieldIndex, field.column+".")
+			continue
+		}
+		for _, option := range options[1:] {
+			switch option {
+			case "omitempty":
+				field.omitEmpty = true
+			case "readonly":
+				field.readOnly = true
+			case "pk":
+				field.pk = true
+			}
+		}
+		fields = append(fields, field)
+	}
+	return fields
+}
+
+// structValue dereferences v down to a struct value, panicking if v is not a
+// struct or a non-nil pointer to one.
+func structValue(v interface{}) reflect.Value {
+	val := reflect.ValueOf(v)
+	for val.Kind() == reflect.Ptr && !val.IsNil() {
+		val = val.Elem()
+	}
+	if val.Kind() != reflect.Struct {
+		panic(fmt.Sprintf("expected a struct or a pointer to one, not %T", v))
+	}
+	return val
+}
+
+// fieldValue returns the field of v at index. ok is false when the path goes
+// through a nil embedded pointer.
+func fieldValue(v reflect.Value, index []int) (field reflect.Value, ok bool) {
+	for i, x := range index {
+		if i > 0 && v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				return reflect.Value{}, false
+			}
+			v = v.Elem()
+		}
+		v = v.Field(x)
+	}
+	return v, true
+}
+
+// StructOption configures how UpdateBuilder.SetStruct maps a struct.
+type StructOption func(*structOptions)
+
+type structOptions struct {
+	only    map[string]bool
+	omit    map[string]bool
+	wherePK bool
+}
+
+// OnlyColumns restricts SetStruct to the given columns.
+func OnlyColumns(columns ...string) StructOption {
+	return func(o *structOptions) {
+		o.only = make(map[string]bool, len(columns))
+		for _, column := range columns {
+			o.only[column] = true
+		}
+	}
+}
+
+// OmitColumns makes SetStruct skip the given columns.
+func OmitColumns(columns ...string) StructOption {
+	return func(o *structOptions) {
+		o.omit = make(map[string]bool, len(columns))
+		for _, column := range columns {
+			o.omit[column] = true
+		}
+	}
+}
+
+// WherePK makes SetStruct add "<pk> = ?" to the WHERE clause for each field
+// tagged pk.
+func WherePK() StructOption {
+	return func(o *structOptions) {
+		o.wherePK = true
+	}
+}
+
+// structColumn is a column and value read from a struct.
+type structColumn struct {
+	column string
+	value  interface{}
+	pk     bool
+}
+
+// structColumns returns the columns of v to be written by an insert or
+// update, skipping readonly fields and empty omitempty fields.
+func structColumns(v interface{}, o structOptions) []structColumn {
+	var columns []structColumn
+	val := structValue(v)
+	for _, f := range structFields(val.Type()) {
+		field, ok := fieldValue(val, f.index)
+		switch {
+		case f.joined,
+			f.readOnly,
+			f.omitEmpty && (!ok || field.IsZero()),
+			o.only != nil && !o.only[f.column] && !(f.pk && o.wherePK),
+			o.omit[f.column]:
+			continue
+		}
+		var value interface{}
+		if ok {
+			value = field.Interface()
+		}
+		columns = append(columns, structColumn{column: f.column, value: value, pk: f.pk})
+	}
+	return columns
+}
+
+// structSelectColumns returns the result columns of the struct v qualified
+// with alias. Columns of joined structs are qualified with their prefix and
+// aliased back to it so that they can be scanned, as in
+// `author.id AS "author.id"`.
+func structSelectColumns(v interface{}, alias string) []string {
+	fields := structFields(structValue(v).Type())
+	columns := make([]string, 0, len(fields))
+	for _, f := range fields {
+		switch {
+		case f.joined:
+			columns = append(columns, fmt.Sprintf("%s AS \"%s\"", f.column, f.column))
+		case alias != "":
+			columns = append(columns, alias+"."+f.column)
+		default:
+			columns = append(columns, f.column)
+		}
+	}
+	return columns
+}
@@ -0,0 +1,84 @@
+package squirrel
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testTimestamps struct {
+	CreatedAt time.Time `db:"created_at,readonly"`
+	UpdatedAt time.Time `db:"updated_at,omitempty"`
+}
+
+type testUser struct {
+	ID    int64  `db:"id,pk,omitempty"`
+	Name  string `db:"name"`
+	Email string `db:",omitempty"`
+	Notes string
+	Skip  string `db:"-"`
+	testTimestamps
+}
+
+func TestStructFields(t *testing.T) {
+	fields := structFields(reflect.TypeOf(testUser{}))
+
+	var columns []string
+	for _, f := range fields {
+		columns = append(columns, f.column)
+	}
+	assert.Equal(t, []string{"id", "name", "email", "created_at", "updated_at"}, columns)
+	assert.True(t, fields[0].pk)
+	assert.True(t, fields[0].omitEmpty)
+	assert.True(t, fields[3].readOnly)
+	assert.Equal(t, []int{5, 1}, fields[4].index)
+}
+
+func TestStructColumnsNilEmbedded(t *testing.T) {
+	type withPtr struct {
+		*testTimestamps
+		Name string `db:"name"`
+	}
+	columns := structColumns(withPtr{Name: "moe"}, structOptions{})
+	assert.Equal(t, []structColumn{{column: "name", value: "moe"}}, columns)
+}
+
+func TestStructValuePanics(t *testing.T) {
+	assert.Panics(t, func() { structValue(1) })
+}
+
+type testAuthor struct {
+	ID   int64  `db:"id"`
+	Name string `db:"name"`
+}
+
+type testPost struct {
+	ID     int64       `db:"id"`
+	Title  string      `db:"title"`
+	Author *testAuthor `db:"author"`
+	testTimestamps
+}
+
+func TestStructSelectColumns(t *testing.T) {
+	columns := structSelectColumns(testPost{}, "p")
+	expected := []string{
+		"p.id", "p.title",
+		`author.id AS "author.id"`, `author.name AS "author.name"`,
+		"p.created_at", "p.updated_at",
+	}
+	assert.Equal(t, expected, columns)
+
+	assert.Equal(t, []string{"id", "name"}, structSelectColumns(&testAuthor{}, ""))
+}
+
+func TestStructColumnsSkipJoined(t *testing.T) {
+	post := testPost{ID: 1, Title: "hi", Author: &testAuthor{ID: 2}}
+	columns := structColumns(post, structOptions{})
+	var names []string
+	for _, c := range columns {
+		names = append(names, c.column)
+	}
+	assert.Equal(t, []string{"id", "title"}, names)
+}
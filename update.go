@@ -2,7 +2,11 @@ package squirrel
 
 import (
 	"bytes"
+	"context"
+	"database/sql"
+	"errors"
 	"fmt"
+	"io"
 	"sort"
 	"strings"
 
@@ -10,33 +14,137 @@ import (
 )
 
 type updateData struct {
+	statementPolicies
 	PlaceholderFormat PlaceholderFormat
+	Dialect           Dialect
+	RunWith           BaseRunner
+	AllRows           bool
 	Prefixes          exprs
 	Table             string
 	SetClauses        []setClause
+	BulkSet           *bulkSet
+	BulkSetTypes      map[string]string
+	From              Sqlizer
+	Joins             []Sqlizer
 	WhereParts        []Sqlizer
 	GroupBys          []string
 	HavingParts       []Sqlizer
 	OrderBys          []string
 	Limit             string
 	Offset            string
+	Returning         []string
 	Suffixes          exprs
+	WithDeleted       bool
+	Version           *versionCheck
+
+	// ctx is the context the statement is run with, for Audit.
+	ctx context.Context
+	// versionWhere is the WHERE predicate of WithVersion. Unlike WhereParts
+	// it does not bound the statement for RequireWhere.
+	versionWhere Sqlizer
 }
 
+// setClause is a "column = value" assignment. A Sqlizer value is rendered in
+// place, as in "n = n + ?" or "x = DEFAULT", and a SelectBuilder as a
+// parenthesized subquery; any other value is bound to a placeholder.
 type setClause struct {
 	column string
 	value  interface{}
 }
 
+func (c setClause) appendToSql(w io.Writer, args []interface{}) ([]interface{}, error) {
+	valSql := "?"
+	if s, ok := c.value.(Sqlizer); ok {
+		_, subquery := s.(SelectBuilder)
+		if subquery {
+			s = questionPlaceholders(s)
+		}
+		vSql, vArgs, err := s.ToSql()
+		if err != nil {
+			return args, err
+		}
+		valSql = vSql
+		if subquery {
+			valSql = "(" + vSql + ")"
+		}
+		args = append(args, vArgs...)
+	} else {
+		args = append(args, c.value)
+	}
+	_, err := fmt.Fprintf(w, "%s = %s", c.column, valSql)
+	return args, err
+}
+
 func (d *updateData) ToSql() (sqlStr string, args []interface{}, err error) {
 	if len(d.Table) == 0 {
 		err = fmt.Errorf("update statements must specify a table")
 		return
 	}
+	if len(d.GroupBys) > 0 || len(d.HavingParts) > 0 {
+		err = fmt.Errorf("update statements do not support GROUP BY or HAVING")
+		return
+	}
+	if d.Audit != nil {
+		audited := *d
+		audited.Audit = nil
+		set := make(map[string]bool, len(d.SetClauses))
+		for _, c := range d.SetClauses {
+			set[c.column] = true
+		}
+		if d.BulkSet != nil {
+			for _, column := range d.BulkSet.sortedColumns() {
+				set[column] = true
+			}
+		}
+		columns, values := d.Audit.columns(d.ctx, false, func(column string) bool { return set[column] })
+		audited.SetClauses = d.SetClauses[:len(d.SetClauses):len(d.SetClauses)]
+		for i, column := range columns {
+			audited.SetClauses = append(audited.SetClauses, setClause{column: column, value: values[i]})
+		}
+		return audited.ToSql()
+	}
+	if d.Version != nil {
+		versioned := *d
+		versioned.Version = nil
+		versioned.SetClauses = append(d.SetClauses[:len(d.SetClauses):len(d.SetClauses)],
+			setClause{column: d.Version.column, value: Expr(d.Version.column + " + 1")})
+		versioned.versionWhere = Eq{d.Version.column: d.Version.expected}
+		return versioned.ToSql()
+	}
+	if d.BulkSet != nil {
+		bulk := *d
+		sets, from, where, bulkErr := d.bulkSetParts()
+		if bulkErr != nil {
+			err = bulkErr
+			return
+		}
+		bulk.BulkSet = nil
+		bulk.SetClauses = append(sets, d.SetClauses...)
+		if from != nil {
+			bulk.From = from
+		}
+		bulk.WhereParts = append([]Sqlizer{where}, d.WhereParts...)
+		return bulk.ToSql()
+	}
 	if len(d.SetClauses) == 0 {
 		err = fmt.Errorf("update statements must have at least one Set clause")
 		return
 	}
+	// MySQL joins the other tables to the updated one before SET, the others
+	// list them in a FROM clause after it.
+	joinFirst := d.Dialect == MySQL || (d.Dialect == "" && d.From == nil)
+	if len(d.Joins) > 0 && d.From == nil && (d.Dialect == PostgreSQL || d.Dialect == SQLite) {
+		err = fmt.Errorf("%s update statements can only join tables to From", d.Dialect)
+		return
+	}
+
+	var returning string
+	if len(d.Returning) > 0 {
+		returning, err = d.Dialect.returningClause(d.Returning, "INSERTED")
+		if err != nil {
+			return
+		}
+	}
 
 	sql := &bytes.Buffer{}
 
@@ -45,37 +153,108 @@ func (d *updateData) ToSql() (sqlStr string, args []interface{}, err error) {
 		sql.WriteString(" ")
 	}
 
+	joins, joinScopes, err := d.scopeJoins(d.Joins, d.WithDeleted)
+	if err != nil {
+		return
+	}
+	joins, err = d.resolveJoins(joins)
+	if err != nil {
+		return
+	}
+	joined := d.From != nil || len(d.Joins) > 0
+
 	sql.WriteString("UPDATE ")
-	sql.WriteString(d.Table)
+	if d.Dialect == SQLServer && d.From == nil && len(d.Joins) > 0 {
+		// The table is resolved in the FROM clause, aliased with its name.
+		sql.WriteString(d.Table)
+	} else {
+		sql.WriteString(d.resolveTable(d.Table, d.Dialect != SQLServer))
+	}
+
+	if joinFirst {
+		if d.From != nil {
+			sql.WriteString(", ")
+			args, err = appendToSql([]Sqlizer{d.resolveFrom(d.From, true)}, sql, "", args)
+			if err != nil {
+				return
+			}
+		}
+		if len(joins) > 0 {
+			sql.WriteString(" ")
+			args, err = appendToSql(joins, sql, " ", args)
+			if err != nil {
+				return
+			}
+		}
+	}
 
 	sql.WriteString(" SET ")
-	setSqls := make([]string, len(d.SetClauses))
-	for i, setClause := range d.SetClauses {
-		var valSql string
-		e, isExpr := setClause.value.(expr)
-		if isExpr {
-			valSql = e.sql
-			args = append(args, e.args...)
-		} else {
-			valSql = "?"
-			args = append(args, setClause.value)
-		}
-		if ok, column := getSetColumn(setClause.column); ok {
-			setSqls[i] = fmt.Sprintf("%s%s", column, valSql)
-		} else {
-			setSqls[i] = fmt.Sprintf("%s = %s", column, valSql)
-		}
-	}
-	sql.WriteString(strings.Join(setSqls, ", "))
-
-	if len(d.WhereParts) > 0 {
-		sql.WriteString(" WHERE ")
-		args, err = appendToSql(d.WhereParts, sql, " AND ", args)
+	for i, set := range d.SetClauses {
+		if i > 0 {
+			sql.WriteString(", ")
+		}
+		if set.value == Default && d.Dialect == SQLite {
+			err = fmt.Errorf("sqlite does not support DEFAULT in SET, column %s", set.column)
+			return
+		}
+		args, err = set.appendToSql(sql, args)
 		if err != nil {
 			return
 		}
 	}
 
+	if len(returning) > 0 && d.Dialect == SQLServer {
+		sql.WriteString(" ")
+		sql.WriteString(returning)
+	}
+
+	if !joinFirst && (d.From != nil || len(d.Joins) > 0) {
+		sql.WriteString(" FROM ")
+		from := d.From
+		if from == nil {
+			from = newPart(d.Table)
+		}
+		args, err = appendToSql([]Sqlizer{d.resolveFrom(from, true)}, sql, "", args)
+		if err != nil {
+			return
+		}
+		if len(joins) > 0 {
+			sql.WriteString(" ")
+			args, err = appendToSql(joins, sql, " ", args)
+			if err != nil {
+				return
+			}
+		}
+	}
+
+	if err = d.checkTenantTables(d.Table); err != nil {
+		return
+	}
+	fromScopes, err := d.fromScopes(d.From, true, d.WithDeleted)
+	if err != nil {
+		return
+	}
+	scopes := append(d.tableScopes(d.Table, joined, d.WithDeleted), fromScopes...)
+	scopes = append(scopes, joinScopes...)
+	if d.versionWhere != nil {
+		scopes = append(scopes, d.versionWhere)
+	}
+
+	var hasWhere bool
+	args, hasWhere, err = appendScopedWhereToSql(d.WhereParts, scopes, sql, args)
+	if err != nil {
+		return
+	}
+	if d.RequireWhere && !hasWhere && !d.AllRows {
+		err = ErrMissingWhere
+		return
+	}
+
+	if len(returning) > 0 && d.Dialect != SQLServer {
+		sql.WriteString(" ")
+		sql.WriteString(returning)
+	}
+
 	if len(d.OrderBys) > 0 {
 		sql.WriteString(" ORDER BY ")
 		sql.WriteString(strings.Join(d.OrderBys, ", "))
@@ -99,13 +278,138 @@ func (d *updateData) ToSql() (sqlStr string, args []interface{}, err error) {
 	sqlStr, err = d.PlaceholderFormat.ReplacePlaceholders(sql.String())
 	return
 }
-func getSetColumn(column string) (bool, string) {
-	r := []rune(column)
-	str := string(r[:3])
-	if str == "=-=" || str == "=+=" {
-		return true, string(r[3:])
+
+func (d *updateData) Exec() (sql.Result, error) {
+	if d.RunWith == nil {
+		return nil, RunnerNotSet
+	}
+	res, err := ExecWith(d.RunWith, d)
+	if err != nil {
+		return res, err
+	}
+	return res, d.checkVersion(res)
+}
+
+func (d *updateData) Query() (*sql.Rows, error) {
+	if d.RunWith == nil {
+		return nil, RunnerNotSet
+	}
+	if d.Version != nil {
+		return nil, errVersionedQuery
+	}
+	return QueryWith(d.RunWith, d)
+}
+
+func (d *updateData) QueryRow() RowScanner {
+	if d.RunWith == nil {
+		return &Row{err: RunnerNotSet}
+	}
+	queryRower, ok := d.RunWith.(QueryRower)
+	if !ok {
+		return &Row{err: RunnerNotQueryRunner}
+	}
+	return d.versionRow(QueryRowWith(queryRower, d))
+}
+
+func (d *updateData) ExecContext(ctx context.Context) (sql.Result, error) {
+	if d.RunWith == nil {
+		return nil, RunnerNotSet
+	}
+	ctxRunner, ok := d.RunWith.(ExecerContext)
+	if !ok {
+		return nil, NoContextSupport
+	}
+	res, err := ExecContextWith(ctx, ctxRunner, d.withContext(ctx))
+	if err != nil {
+		return res, err
+	}
+	return res, d.checkVersion(res)
+}
+
+func (d *updateData) QueryContext(ctx context.Context) (*sql.Rows, error) {
+	if d.RunWith == nil {
+		return nil, RunnerNotSet
+	}
+	ctxRunner, ok := d.RunWith.(QueryerContext)
+	if !ok {
+		return nil, NoContextSupport
+	}
+	if d.Version != nil {
+		return nil, errVersionedQuery
+	}
+	return QueryContextWith(ctx, ctxRunner, d.withContext(ctx))
+}
+
+func (d *updateData) QueryRowContext(ctx context.Context) RowScanner {
+	if d.RunWith == nil {
+		return &Row{err: RunnerNotSet}
+	}
+	queryRower, ok := d.RunWith.(QueryRowerContext)
+	if !ok {
+		if _, ok := d.RunWith.(QueryerContext); !ok {
+			return &Row{err: RunnerNotQueryRunner}
+		}
+		return &Row{err: NoContextSupport}
+	}
+	return d.versionRow(QueryRowContextWith(ctx, queryRower, d.withContext(ctx)))
+}
+
+// withContext returns a copy of d run with ctx.
+func (d updateData) withContext(ctx context.Context) *updateData {
+	d.ctx = ctx
+	return &d
+}
+
+// versionCheck is the version column and its expected value set by
+// WithVersion.
+type versionCheck struct {
+	column   string
+	expected interface{}
+}
+
+// ErrStaleVersion is returned by the Exec, QueryRow and Scan methods of an
+// UpdateBuilder using WithVersion when no row had the expected version.
+var ErrStaleVersion = errors.New("no row matched the expected version, it was changed or deleted")
+
+// errVersionedQuery is returned by the Query methods of an UpdateBuilder
+// using WithVersion, which cannot tell a stale version from the rows.
+var errVersionedQuery = errors.New("versioned updates cannot be run with Query, use Exec or QueryRow")
+
+// checkVersion returns ErrStaleVersion if a versioned update affected no rows.
+func (d *updateData) checkVersion(res sql.Result) error {
+	if d.Version == nil {
+		return nil
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return ErrStaleVersion
+	}
+	return nil
+}
+
+// versionRow returns row, reporting ErrStaleVersion instead of sql.ErrNoRows
+// if a versioned update returned no row.
+func (d *updateData) versionRow(row RowScanner) RowScanner {
+	if d.Version == nil {
+		return row
 	}
-	return false, column
+	return &staleVersionRow{row}
+}
+
+// staleVersionRow is a RowScanner of a versioned update, see versionRow.
+type staleVersionRow struct {
+	RowScanner
+}
+
+func (r *staleVersionRow) Scan(dest ...interface{}) error {
+	err := r.RowScanner.Scan(dest...)
+	if err == sql.ErrNoRows {
+		return ErrStaleVersion
+	}
+	return err
 }
 
 // Builder
@@ -121,10 +425,74 @@ func init() {
 
 // PlaceholderFormat sets PlaceholderFormat (e.g. Question or Dollar) for the
 // query.
-func (b UpdateBuilder) PlaceholderFormat(f PlaceholderFormat) WhereConditions {
+func (b UpdateBuilder) PlaceholderFormat(f PlaceholderFormat) UpdateBuilder {
 	return builder.Set(b, "PlaceholderFormat", f).(UpdateBuilder)
 }
 
+// Dialect sets the Dialect (e.g. MySQL or PostgreSQL) the query is rendered
+// for.
+func (b UpdateBuilder) Dialect(d Dialect) UpdateBuilder {
+	return builder.Set(b, "Dialect", d).(UpdateBuilder)
+}
+
+// Runner methods
+
+// RunWith sets a Runner (like database/sql.DB) to be used with e.g. Exec.
+// For most cases runner will be a database connection.
+//
+// Internally we use this to mock out the database connection for testing.
+func (b UpdateBuilder) RunWith(runner BaseRunner) UpdateBuilder {
+	return setRunWith(b, runner).(UpdateBuilder)
+}
+
+// Exec builds and Execs the query with the Runner set by RunWith.
+func (b UpdateBuilder) Exec() (sql.Result, error) {
+	data := builder.GetStruct(b).(updateData)
+	return data.Exec()
+}
+
+// Query builds and Querys the query with the Runner set by RunWith.
+func (b UpdateBuilder) Query() (*sql.Rows, error) {
+	data := builder.GetStruct(b).(updateData)
+	return data.Query()
+}
+
+// QueryRow builds and QueryRows the query with the Runner set by RunWith.
+func (b UpdateBuilder) QueryRow() RowScanner {
+	data := builder.GetStruct(b).(updateData)
+	return data.QueryRow()
+}
+
+// Scan is a shortcut for QueryRow().Scan.
+func (b UpdateBuilder) Scan(dest ...interface{}) error {
+	return b.QueryRow().Scan(dest...)
+}
+
+// ExecContext builds and ExecContexts the query with the Runner set by RunWith.
+func (b UpdateBuilder) ExecContext(ctx context.Context) (sql.Result, error) {
+	data := builder.GetStruct(b).(updateData)
+	return data.ExecContext(ctx)
+}
+
+// QueryContext builds and QueryContexts the query with the Runner set by
+// RunWith.
+func (b UpdateBuilder) QueryContext(ctx context.Context) (*sql.Rows, error) {
+	data := builder.GetStruct(b).(updateData)
+	return data.QueryContext(ctx)
+}
+
+// QueryRowContext builds and QueryRowContexts the query with the Runner set by
+// RunWith.
+func (b UpdateBuilder) QueryRowContext(ctx context.Context) RowScanner {
+	data := builder.GetStruct(b).(updateData)
+	return data.QueryRowContext(ctx)
+}
+
+// ScanContext is a shortcut for QueryRowContext().Scan.
+func (b UpdateBuilder) ScanContext(ctx context.Context, dest ...interface{}) error {
+	return b.QueryRowContext(ctx).Scan(dest...)
+}
+
 // SQL methods
 
 // ToSql builds the query into a SQL string and bound args.
@@ -134,32 +502,137 @@ func (b UpdateBuilder) ToSql() (string, []interface{}, error) {
 }
 
 // Prefix adds an expression to the beginning of the query
-func (b UpdateBuilder) Prefix(sql string, args ...interface{}) UpdateCondition {
+func (b UpdateBuilder) Prefix(sql string, args ...interface{}) UpdateBuilder {
 	return builder.Append(b, "Prefixes", Expr(sql, args...)).(UpdateBuilder)
 }
 
 // Table sets the table to be updated.
-func (b UpdateBuilder) Table(table string) UpdateCondition {
+func (b UpdateBuilder) Table(table string) UpdateBuilder {
 	return builder.Set(b, "Table", table).(UpdateBuilder)
 }
 
-// Set adds SET clauses to the query.
-func (b UpdateBuilder) Set(column string, value interface{}) UpdateCondition {
+// From adds a table the updated rows are matched against, rendered as
+// "UPDATE t SET ... FROM s" or as "UPDATE t, s SET ..." for MySQL.
+func (b UpdateBuilder) From(from string) UpdateBuilder {
+	return builder.Set(b, "From", newPart(from)).(UpdateBuilder)
+}
+
+// FromSelect adds a subquery the updated rows are matched against.
+//
+// See From.
+func (b UpdateBuilder) FromSelect(from SelectCondition, alias string) UpdateBuilder {
+	return builder.Set(b, "From", Alias(from.PlaceholderFormat(Question), alias)).(UpdateBuilder)
+}
+
+// JoinClause adds a join clause to the query.
+//
+// MySQL and statements without a Dialect join to the updated table, as in
+// "UPDATE t JOIN s ON ... SET ...". PostgreSQL and SQLite join to the table
+// set by From, and SQLServer to From or else the updated table, in a FROM
+// clause after SET.
+func (b UpdateBuilder) JoinClause(pred interface{}, args ...interface{}) UpdateBuilder {
+	return builder.Append(b, "Joins", newPart(pred, args...)).(UpdateBuilder)
+}
+
+// Join adds a JOIN clause to the query.
+//
+// See JoinClause.
+func (b UpdateBuilder) Join(join string, rest ...interface{}) UpdateBuilder {
+	return b.JoinClause("JOIN "+join, rest...)
+}
+
+// LeftJoin adds a LEFT JOIN clause to the query.
+//
+// See JoinClause.
+func (b UpdateBuilder) LeftJoin(join string, rest ...interface{}) UpdateBuilder {
+	return b.JoinClause("LEFT JOIN "+join, rest...)
+}
+
+// Set adds SET clauses to the query. A Sqlizer value like Expr is rendered
+// in place, any other value is bound to a placeholder.
+func (b UpdateBuilder) Set(column string, value interface{}) UpdateBuilder {
 	return builder.Append(b, "SetClauses", setClause{column: column, value: value}).(UpdateBuilder)
 }
 
-func (b UpdateBuilder) IncrBy(column string, num int) UpdateCondition {
-	column = fmt.Sprintf("=+=%s = %s+", column, column)
-	return builder.Append(b, "SetClauses", setClause{column: column, value: num}).(UpdateBuilder)
+// BulkSet sets different values for each row matched by keyColumn in a
+// single statement. rows maps each key to the columns to set on its row:
+//
+//	Update("users").BulkSet("id", map[interface{}]map[string]interface{}{
+//	    1: {"name": "moe"},
+//	    2: {"name": "larry"},
+//	})
+//	// UPDATE users SET name = CASE id WHEN ? THEN ? WHEN ? THEN ? ELSE name END
+//	// WHERE id IN (?,?)
+//
+// PostgreSQL joins a VALUES list instead, and needs every row to set the same
+// columns:
+//
+//	// UPDATE users SET name = v.name FROM (VALUES ($1::bigint, $2::text), ...)
+//	// AS v(id, name) WHERE users.id = v.id
+//
+// Its values are cast to the type of their Go type, see BulkSetTypes for
+// columns of other types. A Sqlizer value like Expr("?::jsonb", doc) is
+// rendered as is.
+func (b UpdateBuilder) BulkSet(keyColumn string, rows map[interface{}]map[string]interface{}) UpdateBuilder {
+	return builder.Set(b, "BulkSet", &bulkSet{key: keyColumn, rows: rows}).(UpdateBuilder)
+}
+
+// BulkSetTypes declares the PostgreSQL types of BulkSet columns, keyColumn
+// included, for the VALUES list to cast their values to, as uuid, enum and
+// jsonb columns do not accept the text PostgreSQL types strings as.
+//
+// Ex:
+//
+//	Update("users").
+//	    BulkSet("id", rows).
+//	    BulkSetTypes(map[string]string{"id": "uuid", "role": "user_role"}).
+//	    Dialect(PostgreSQL)
+//	// ... FROM (VALUES ($1::uuid, $2::user_role), ...) AS v(id, role) ...
+func (b UpdateBuilder) BulkSetTypes(types map[string]string) UpdateBuilder {
+	return builder.Set(b, "BulkSetTypes", types).(UpdateBuilder)
+}
+
+// SetExpr adds "column = <expr>" to the SET clause.
+func (b UpdateBuilder) SetExpr(column string, expr Sqlizer) UpdateBuilder {
+	return b.Set(column, expr)
+}
+
+// Incr adds "column = column + ?" to the SET clause.
+func (b UpdateBuilder) Incr(column string, delta interface{}) UpdateBuilder {
+	return b.Set(column, Expr(column+" + ?", delta))
+}
+
+// Decr adds "column = column - ?" to the SET clause.
+func (b UpdateBuilder) Decr(column string, delta interface{}) UpdateBuilder {
+	return b.Set(column, Expr(column+" - ?", delta))
+}
+
+// SetDefault adds "column = DEFAULT" to the SET clause.
+func (b UpdateBuilder) SetDefault(column string) UpdateBuilder {
+	return b.Set(column, Default)
 }
 
-func (b UpdateBuilder) DecrBy(column string, num int) UpdateCondition {
-	column = fmt.Sprintf("=-=%s = %s+", column, column)
-	return builder.Append(b, "SetClauses", setClause{column: column, value: num}).(UpdateBuilder)
+// SetNull adds "column = NULL" to the SET clause.
+func (b UpdateBuilder) SetNull(column string) UpdateBuilder {
+	return b.Set(column, Expr("NULL"))
+}
+
+// IncrBy adds "column = column + ?" to the SET clause.
+//
+// Deprecated: use Incr.
+func (b UpdateBuilder) IncrBy(column string, num int) UpdateBuilder {
+	return b.Incr(column, num)
+}
+
+// DecrBy adds "column = column - ?" to the SET clause.
+//
+// Deprecated: use Decr.
+func (b UpdateBuilder) DecrBy(column string, num int) UpdateBuilder {
+	return b.Decr(column, num)
 }
 
 // SetMap is a convenience method which calls .Set for each key/value pair in clauses.
-func (b UpdateBuilder) SetMap(clauses map[string]interface{}) UpdateCondition {
+func (b UpdateBuilder) SetMap(clauses map[string]interface{}) UpdateBuilder {
 	keys := make([]string, len(clauses))
 	i := 0
 	for key := range clauses {
@@ -169,7 +642,7 @@ func (b UpdateBuilder) SetMap(clauses map[string]interface{}) UpdateCondition {
 	sort.Strings(keys)
 	for _, key := range keys {
 		val, _ := clauses[key]
-		b = b.Set(key, val).(UpdateBuilder)
+		b = b.Set(key, val)
 	}
 	return b
 }
@@ -177,77 +650,172 @@ func (b UpdateBuilder) SetMap(clauses map[string]interface{}) UpdateCondition {
 // Where adds WHERE expressions to the query.
 //
 // See SelectBuilder.Where for more information.
-func (b UpdateBuilder) Where(pred interface{}, args ...interface{}) WhereConditions {
+func (b UpdateBuilder) Where(pred interface{}, args ...interface{}) UpdateBuilder {
 	return builder.Append(b, "WhereParts", newWherePart(pred, args...)).(UpdateBuilder)
 }
 
 //Condition
-func (b UpdateBuilder) Condition() WhereConditions {
+func (b UpdateBuilder) Condition() UpdateBuilder {
 	return builder.Append(b, "WhereParts", newWherePart("")).(UpdateBuilder)
 }
 
 //expr
-func (b UpdateBuilder) Expr(sql string, args ...interface{}) WhereConditions {
+func (b UpdateBuilder) Expr(sql string, args ...interface{}) UpdateBuilder {
 	return builder.Append(b, "WhereParts", newWherePart(expr{sql: sql, args: args})).(UpdateBuilder)
 }
 
 //eq
-func (b UpdateBuilder) Eq(column string, arg interface{}) WhereConditions {
+func (b UpdateBuilder) Eq(column string, arg interface{}) UpdateBuilder {
 	return b.Where(Eq{column: arg})
 }
 
-func (b UpdateBuilder) NotEq(column string, arg interface{}) WhereConditions {
+func (b UpdateBuilder) NotEq(column string, arg interface{}) UpdateBuilder {
 	return b.Where(NotEq{column: arg})
 }
 
 //gt
-func (b UpdateBuilder) Gt(column string, arg interface{}) WhereConditions {
+func (b UpdateBuilder) Gt(column string, arg interface{}) UpdateBuilder {
 	return b.Where(Gt{column: arg})
 }
 
 //gtOrEq
-func (b UpdateBuilder) GtOrEq(column string, arg interface{}) WhereConditions {
+func (b UpdateBuilder) GtOrEq(column string, arg interface{}) UpdateBuilder {
 	return b.Where(GtOrEq{column: arg})
 }
 
 //lt
-func (b UpdateBuilder) Lt(column string, arg interface{}) WhereConditions {
+func (b UpdateBuilder) Lt(column string, arg interface{}) UpdateBuilder {
 	return b.Where(Lt{column: arg})
 }
 
 //ltOrEq
-func (b UpdateBuilder) LtOrEq(column string, arg interface{}) WhereConditions {
+func (b UpdateBuilder) LtOrEq(column string, arg interface{}) UpdateBuilder {
 	return b.Where(LtOrEq{column: arg})
 }
 
 // OrderBy adds ORDER BY expressions to the query.
-func (b UpdateBuilder) OrderBy(orderBys ...string) WhereConditions {
+func (b UpdateBuilder) OrderBy(orderBys ...string) UpdateBuilder {
 	return builder.Extend(b, "OrderBys", orderBys).(UpdateBuilder)
 }
 
 // GroupBy adds GROUP BY expressions to the query.
-func (b UpdateBuilder) GroupBy(groupBys ...string) WhereConditions {
+func (b UpdateBuilder) GroupBy(groupBys ...string) UpdateBuilder {
 	return builder.Extend(b, "GroupBys", groupBys).(UpdateBuilder)
 }
 
 // Having adds an expression to the HAVING clause of the query.
 //
 // See Where.
-func (b UpdateBuilder) Having(pred interface{}, rest ...interface{}) WhereConditions {
+func (b UpdateBuilder) Having(pred interface{}, rest ...interface{}) UpdateBuilder {
 	return builder.Append(b, "HavingParts", newWherePart(pred, rest...)).(UpdateBuilder)
 }
 
 // Limit sets a LIMIT clause on the update.
-func (b UpdateBuilder) Limit(limit int) WhereConditions {
+func (b UpdateBuilder) Limit(limit int) UpdateBuilder {
 	return builder.Set(b, "Limit", fmt.Sprintf("%d", limit)).(UpdateBuilder)
 }
 
 // Offset sets a OFFSET clause on the query.
-func (b UpdateBuilder) Offset(offset int) WhereConditions {
+func (b UpdateBuilder) Offset(offset int) UpdateBuilder {
 	return builder.Set(b, "Offset", fmt.Sprintf("%d", offset)).(UpdateBuilder)
 }
 
 // Suffix adds an expression to the end of the query
-func (b UpdateBuilder) Suffix(sql string, args ...interface{}) WhereConditions {
+func (b UpdateBuilder) Suffix(sql string, args ...interface{}) UpdateBuilder {
 	return builder.Append(b, "Suffixes", Expr(sql, args...)).(UpdateBuilder)
 }
+
+// Returning adds columns of the updated rows to be returned by the query, in a
+// RETURNING clause or an OUTPUT clause for SQLServer. Read them with Query or
+// QueryRow.
+func (b UpdateBuilder) Returning(columns ...string) UpdateBuilder {
+	return builder.Extend(b, "Returning", columns).(UpdateBuilder)
+}
+
+// ClearSet removes all SET clauses from the query.
+func (b UpdateBuilder) ClearSet() UpdateBuilder {
+	b = builder.Delete(b, "BulkSet").(UpdateBuilder)
+	b = builder.Delete(b, "BulkSetTypes").(UpdateBuilder)
+	return builder.Delete(b, "SetClauses").(UpdateBuilder)
+}
+
+// AllRows allows the statement to update every row of the table when
+// RequireWhere is set on the StatementBuilderType it was built from.
+func (b UpdateBuilder) AllRows() UpdateBuilder {
+	return builder.Set(b, "AllRows", true).(UpdateBuilder)
+}
+
+// WithVersion makes the update optimistically locked by the integer column:
+// it only updates rows where column = expected and increments column, as in
+// "UPDATE t SET ..., version = version + 1 WHERE ... AND version = ?". Exec,
+// QueryRow and Scan, as used with Returning, and their Context variants return
+// ErrStaleVersion if no row was updated. Query and QueryContext, which cannot
+// tell, return an error. Functions running the statement with a runner of
+// their own, like QueryAll, do not check the version.
+func (b UpdateBuilder) WithVersion(column string, expected interface{}) UpdateBuilder {
+	return builder.Set(b, "Version", &versionCheck{column: column, expected: expected}).(UpdateBuilder)
+}
+
+// WithDeleted also updates the soft deleted rows of the table, and matches the
+// soft deleted rows of the tables it joins.
+//
+// See StatementBuilderType.SoftDelete.
+func (b UpdateBuilder) WithDeleted() UpdateBuilder {
+	return builder.Set(b, "WithDeleted", true).(UpdateBuilder)
+}
+
+// ClearWhere removes all WHERE expressions from the query.
+func (b UpdateBuilder) ClearWhere() UpdateBuilder {
+	return builder.Delete(b, "WhereParts").(UpdateBuilder)
+}
+
+// RemoveOrderBy removes the ORDER BY clause from the query.
+func (b UpdateBuilder) RemoveOrderBy() UpdateBuilder {
+	return builder.Delete(b, "OrderBys").(UpdateBuilder)
+}
+
+// RemoveLimit removes the LIMIT clause from the query.
+func (b UpdateBuilder) RemoveLimit() UpdateBuilder {
+	return builder.Delete(b, "Limit").(UpdateBuilder)
+}
+
+// RemoveOffset removes the OFFSET clause from the query.
+func (b UpdateBuilder) RemoveOffset() UpdateBuilder {
+	return builder.Delete(b, "Offset").(UpdateBuilder)
+}
+
+// Merge adds the WHERE, ORDER BY, LIMIT, OFFSET and suffix parts of a fragment
+// built with Where or Condition to the query. A fragment with GROUP BY or
+// HAVING parts makes ToSql return an error, as update statements have neither.
+//
+// See SelectBuilder.Merge.
+func (b UpdateBuilder) Merge(fragment ConditionFragment) UpdateBuilder {
+	return mergeFragment(b, fragment).(UpdateBuilder)
+}
+
+// SetStruct adds a SET clause for each db tagged field of v, a struct or a
+// pointer to one, in declaration order.
+//
+// Fields tagged pk or readonly are skipped, as are fields tagged omitempty
+// holding their zero value. The OnlyColumns and OmitColumns options restrict
+// the columns set, and WherePK adds "<pk> = ?" to the WHERE clause for the
+// fields tagged pk.
+//
+// SetStruct panics if v is not a struct or a pointer to one.
+func (b UpdateBuilder) SetStruct(v interface{}, opts ...StructOption) UpdateBuilder {
+	var o structOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	for _, c := range structColumns(v, o) {
+		if c.pk {
+			if o.wherePK {
+				b = b.Where(Eq{c.column: c.value})
+			}
+			continue
+		}
+		b = b.Set(c.column, c.value)
+	}
+	return b
+}
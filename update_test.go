@@ -1,6 +1,8 @@
 package squirrel
 
 import (
+	"context"
+	"database/sql"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -50,24 +52,251 @@ func TestUpdateBuilderPlaceholders(t *testing.T) {
 	assert.Equal(t, "UPDATE test SET x = $1, y = $2", sql)
 }
 
-//func TestUpdateBuilderRunners(t *testing.T) {
-//	db := &DBStub{}
-//	b := Update("test").Set("x", 1).RunWith(db)
-//
-//	expectedSql := "UPDATE test SET x = ?"
-//
-//	b.Exec()
-//	assert.Equal(t, expectedSql, db.LastExecSql)
-//}
-//
-//func TestUpdateBuilderNoRunner(t *testing.T) {
-//	b := Update("test").Set("x", 1)
-//
-//	_, err := b.Exec()
-//	assert.Equal(t, RunnerNotSet, err)
-//}
+func TestUpdateBuilderRunners(t *testing.T) {
+	db := &DBStub{}
+	b := Update("test").Set("x", 1).RunWith(db)
+
+	expectedSql := "UPDATE test SET x = ?"
+
+	b.Exec()
+	assert.Equal(t, expectedSql, db.LastExecSql)
+}
+
+func TestUpdateBuilderNoRunner(t *testing.T) {
+	b := Update("test").Set("x", 1)
+
+	_, err := b.Exec()
+	assert.Equal(t, RunnerNotSet, err)
+}
 func TestUpdateBuilder_IncrBy(t *testing.T) {
-	a, _, _ := Update("test").Set("x", 1).IncrBy("a", 1).ToSql()
-	expectedSql := "UPDATE test SET x = ?, a = a+?"
+	a, args, _ := Update("test").Set("x", 1).IncrBy("a", 1).DecrBy("b", 2).ToSql()
+	expectedSql := "UPDATE test SET x = ?, a = a + ?, b = b - ?"
 	assert.Equal(t, expectedSql, a)
+	assert.Equal(t, []interface{}{1, 1, 2}, args)
+}
+
+func TestUpdateBuilderSetExpressions(t *testing.T) {
+	sql, args, err := Update("test").
+		Set("id", 1).
+		Incr("n", 1).
+		Decr("stock", 3).
+		SetExpr("total", Expr("price * ?", 2)).
+		SetDefault("status").
+		SetNull("deleted_at").
+		Where("id = ?", 1).
+		ToSql()
+	assert.NoError(t, err)
+	expectedSql := "UPDATE test SET id = ?, n = n + ?, stock = stock - ?, total = price * ?, " +
+		"status = DEFAULT, deleted_at = NULL WHERE id = ?"
+	assert.Equal(t, expectedSql, sql)
+	assert.Equal(t, []interface{}{1, 1, 3, 2, 1}, args)
+}
+
+func TestUpdateBuilderSetSubquery(t *testing.T) {
+	total := Select("sum(x)").From("items").Where("order_id = orders.id AND kind = ?", "a")
+	sql, args, err := Update("orders").
+		Set("total", total).
+		Where("id = ?", 1).
+		PlaceholderFormat(Dollar).
+		ToSql()
+	assert.NoError(t, err)
+	expectedSql := "UPDATE orders SET total = (SELECT sum(x) FROM items WHERE order_id = orders.id AND kind = $1) " +
+		"WHERE id = $2"
+	assert.Equal(t, expectedSql, sql)
+	assert.Equal(t, []interface{}{"a", 1}, args)
+}
+
+func TestUpdateBuilderMerge(t *testing.T) {
+	tenant := Where("tenant_id = ?", 1)
+	sql, args, err := Update("a").Set("b", 2).Merge(tenant).ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "UPDATE a SET b = ? WHERE tenant_id = ?", sql)
+	assert.Equal(t, []interface{}{2, 1}, args)
+
+	_, _, err = Update("a").Set("b", 2).Merge(Join("c")).Dialect(PostgreSQL).ToSql()
+	assert.Error(t, err)
+
+	_, _, err = Update("a").Set("b", 2).Merge(Where("c = ?", 1).GroupBy("d").Having("count(*) > 1")).ToSql()
+	assert.Error(t, err)
+}
+
+func TestUpdateBuilderFrom(t *testing.T) {
+	b := Update("orders o").
+		Set("o.customer_name", Expr("c.name")).
+		From("customers c").
+		Where("o.customer_id = c.id").
+		Where("o.id = ?", 1)
+
+	sql, args, err := b.ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "UPDATE orders o SET o.customer_name = c.name FROM customers c "+
+		"WHERE o.customer_id = c.id AND o.id = ?", sql)
+	assert.Equal(t, []interface{}{1}, args)
+
+	sql, _, err = b.Dialect(MySQL).ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "UPDATE orders o, customers c SET o.customer_name = c.name "+
+		"WHERE o.customer_id = c.id AND o.id = ?", sql)
+
+	sql, args, err = Update("t").
+		Set("total", Expr("s.total")).
+		FromSelect(Select("id", "SUM(n) AS total").From("items").Where("n > ?", 0).GroupBy("id"), "s").
+		Where("t.id = s.id").
+		Where("t.id = ?", 2).
+		PlaceholderFormat(Dollar).
+		ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "UPDATE t SET total = s.total FROM (SELECT id, SUM(n) AS total FROM items "+
+		"WHERE n > $1 GROUP BY id) AS s WHERE t.id = s.id AND t.id = $2", sql)
+	assert.Equal(t, []interface{}{0, 2}, args)
+}
+
+func TestUpdateBuilderJoin(t *testing.T) {
+	b := Update("orders o").
+		Join("customers c ON c.id = o.customer_id").
+		Set("o.customer_name", Expr("c.name")).
+		Where("c.updated_at > ?", 1)
+
+	sql, _, err := b.ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "UPDATE orders o JOIN customers c ON c.id = o.customer_id "+
+		"SET o.customer_name = c.name WHERE c.updated_at > ?", sql)
+
+	sql, _, err = b.Dialect(SQLServer).ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "UPDATE orders o SET o.customer_name = c.name "+
+		"FROM orders o JOIN customers c ON c.id = o.customer_id WHERE c.updated_at > ?", sql)
+
+	_, _, err = b.Dialect(PostgreSQL).ToSql()
+	assert.Error(t, err)
+
+	sql, _, err = Update("orders o").
+		Set("region", Expr("r.name")).
+		From("customers c").
+		LeftJoin("regions r ON r.id = c.region_id").
+		Where("c.id = o.customer_id").
+		Dialect(PostgreSQL).
+		ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "UPDATE orders o SET region = r.name FROM customers c "+
+		"LEFT JOIN regions r ON r.id = c.region_id WHERE c.id = o.customer_id", sql)
+}
+
+func TestUpdateBuilderRemoveClauses(t *testing.T) {
+	b := Update("a").Set("b", 1).Where("c = ?", 2).OrderBy("d").Limit(3).Offset(4)
+
+	sql, args, err := b.ClearSet().Set("e", 5).ClearWhere().RemoveOrderBy().RemoveLimit().RemoveOffset().ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "UPDATE a SET e = ?", sql)
+	assert.Equal(t, []interface{}{5}, args)
+}
+
+func TestUpdateBuilderReturning(t *testing.T) {
+	b := Update("a").Set("b", 1).Where("c = ?", 2).Returning("id")
+
+	sql, args, err := b.ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "UPDATE a SET b = ? WHERE c = ? RETURNING id", sql)
+	assert.Equal(t, []interface{}{1, 2}, args)
+
+	sql, _, err = b.Dialect(SQLServer).ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "UPDATE a SET b = ? OUTPUT INSERTED.id WHERE c = ?", sql)
+
+	_, _, err = b.Dialect(MySQL).ToSql()
+	assert.Error(t, err)
+}
+
+func TestUpdateBuilderSetStruct(t *testing.T) {
+	user := testUser{ID: 1, Name: "moe", Email: "moe@example.com"}
+
+	sql, args, err := Update("users").SetStruct(user, WherePK()).ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "UPDATE users SET name = ?, email = ? WHERE id = ?", sql)
+	assert.Equal(t, []interface{}{"moe", "moe@example.com", int64(1)}, args)
+
+	sql, args, err = Update("users").SetStruct(user, OnlyColumns("name")).Where("id = ?", 1).ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "UPDATE users SET name = ? WHERE id = ?", sql)
+	assert.Equal(t, []interface{}{"moe", 1}, args)
+
+	sql, _, err = Update("users").SetStruct(user, OmitColumns("email")).ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "UPDATE users SET name = ?", sql)
+}
+
+func TestUpdateBuilderSetDefault(t *testing.T) {
+	b := Update("a").Set("b", Default).Where("c = ?", 1)
+
+	sql, args, err := b.ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "UPDATE a SET b = DEFAULT WHERE c = ?", sql)
+	assert.Equal(t, []interface{}{1}, args)
+
+	_, _, err = b.Dialect(SQLite).ToSql()
+	assert.Error(t, err)
+}
+
+func TestUpdateBuilderRequireWhere(t *testing.T) {
+	b := StatementBuilder.RequireWhere(true).Update("a").Set("b", 1)
+
+	_, _, err := b.ToSql()
+	assert.Equal(t, ErrMissingWhere, err)
+
+	_, _, err = b.Condition().Where("").ToSql()
+	assert.Equal(t, ErrMissingWhere, err)
+
+	sql, _, err := b.AllRows().ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "UPDATE a SET b = ?", sql)
+
+	sql, _, err = b.Where("").Where("c = ?", 2).ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "UPDATE a SET b = ? WHERE c = ?", sql)
+}
+
+func TestUpdateBuilderWithVersion(t *testing.T) {
+	db := &DBStub{}
+	b := Update("docs").Set("body", "x").Where("id = ?", 1).WithVersion("version", 3).RunWith(db)
+
+	sql, args, err := b.ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "UPDATE docs SET body = ?, version = version + 1 WHERE (id = ?) AND version = ?", sql)
+	assert.Equal(t, []interface{}{"x", 1, 3}, args)
+
+	_, err = b.Exec()
+	assert.Equal(t, ErrStaleVersion, err)
+
+	db.RowsAffected = 1
+	_, err = b.ExecContext(context.Background())
+	assert.NoError(t, err)
+
+	_, _, err = StatementBuilder.RequireWhere(true).
+		Update("docs").Set("body", "x").WithVersion("version", 3).
+		ToSql()
+	assert.Equal(t, ErrMissingWhere, err)
+}
+
+func TestUpdateBuilderWithVersionReturning(t *testing.T) {
+	db := &DBStub{}
+	b := Update("docs").Set("body", "x").Where("id = ?", 1).WithVersion("version", 3).
+		Returning("body").
+		RunWith(db)
+
+	_, err := b.Query()
+	assert.Error(t, err)
+
+	_, err = b.QueryContext(context.Background())
+	assert.Error(t, err)
+
+	var body string
+	err = b.Scan(&body)
+	assert.NoError(t, err)
+
+	db.err = sql.ErrNoRows
+	err = b.Scan(&body)
+	assert.Equal(t, ErrStaleVersion, err)
+
+	err = b.ScanContext(context.Background(), &body)
+	assert.Equal(t, ErrStaleVersion, err)
 }